@@ -0,0 +1,77 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// Balloon Memory balloon of a VM, the guest memory statistics are in bytes
+//
+// swagger:model Balloon
+type Balloon struct {
+
+	// Size of the balloon the guest holds.
+	ActualMib int64 `json:"actualMib,omitempty"`
+
+	// available memory
+	AvailableMemory int64 `json:"availableMemory,omitempty"`
+
+	// disk caches
+	DiskCaches int64 `json:"diskCaches,omitempty"`
+
+	// free memory
+	FreeMemory int64 `json:"freeMemory,omitempty"`
+
+	// major faults
+	MajorFaults int64 `json:"majorFaults,omitempty"`
+
+	// minor faults
+	MinorFaults int64 `json:"minorFaults,omitempty"`
+
+	// swap in
+	SwapIn int64 `json:"swapIn,omitempty"`
+
+	// swap out
+	SwapOut int64 `json:"swapOut,omitempty"`
+
+	// Size of the balloon the guest is requested to hold.
+	TargetMib int64 `json:"targetMib,omitempty"`
+
+	// total memory
+	TotalMemory int64 `json:"totalMemory,omitempty"`
+}
+
+// Validate validates this balloon
+func (m *Balloon) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this balloon based on context it is used
+func (m *Balloon) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Balloon) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Balloon) UnmarshalBinary(b []byte) error {
+	var res Balloon
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
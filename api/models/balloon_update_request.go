@@ -0,0 +1,76 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// BalloonUpdateRequest Balloon size
+//
+// swagger:model BalloonUpdateRequest
+type BalloonUpdateRequest struct {
+
+	// Size of the balloon in MiB.
+	// Required: true
+	// Minimum: 0
+	AmountMib *int64 `json:"amountMib"`
+}
+
+// Validate validates this balloon update request
+func (m *BalloonUpdateRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAmountMib(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *BalloonUpdateRequest) validateAmountMib(formats strfmt.Registry) error {
+
+	if err := validate.Required("amountMib", "body", m.AmountMib); err != nil {
+		return err
+	}
+
+	if err := validate.MinimumInt("amountMib", "body", *m.AmountMib, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this balloon update request based on context it is used
+func (m *BalloonUpdateRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *BalloonUpdateRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *BalloonUpdateRequest) UnmarshalBinary(b []byte) error {
+	var res BalloonUpdateRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,135 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Bandwidth Traffic limits of a network interface applied by the bandwidth plugin, requires CNI.
+// A rate and its burst must be set together.
+//
+// swagger:model Bandwidth
+type Bandwidth struct {
+
+	// Egress burst in bits.
+	// Minimum: 0
+	EgressBurst *int64 `json:"egressBurst,omitempty"`
+
+	// Egress rate in bits per second.
+	// Minimum: 0
+	EgressRate *int64 `json:"egressRate,omitempty"`
+
+	// Ingress burst in bits.
+	// Minimum: 0
+	IngressBurst *int64 `json:"ingressBurst,omitempty"`
+
+	// Ingress rate in bits per second.
+	// Minimum: 0
+	IngressRate *int64 `json:"ingressRate,omitempty"`
+}
+
+// Validate validates this bandwidth
+func (m *Bandwidth) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateEgressBurst(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEgressRate(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIngressBurst(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIngressRate(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Bandwidth) validateEgressBurst(formats strfmt.Registry) error {
+	if swag.IsZero(m.EgressBurst) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("egressBurst", "body", *m.EgressBurst, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Bandwidth) validateEgressRate(formats strfmt.Registry) error {
+	if swag.IsZero(m.EgressRate) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("egressRate", "body", *m.EgressRate, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Bandwidth) validateIngressBurst(formats strfmt.Registry) error {
+	if swag.IsZero(m.IngressBurst) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("ingressBurst", "body", *m.IngressBurst, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Bandwidth) validateIngressRate(formats strfmt.Registry) error {
+	if swag.IsZero(m.IngressRate) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("ingressRate", "body", *m.IngressRate, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this bandwidth based on context it is used
+func (m *Bandwidth) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Bandwidth) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Bandwidth) UnmarshalBinary(b []byte) error {
+	var res Bandwidth
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// BootProfile Named boot configuration, the empty fields keep the configuration of the server
+//
+// swagger:model BootProfile
+type BootProfile struct {
+
+	// Path of the initrd image.
+	Initrd string `json:"initrd,omitempty"`
+
+	// Kernel command line.
+	KernelArgs string `json:"kernelArgs,omitempty"`
+
+	// Path of the kernel image.
+	KernelImage string `json:"kernelImage,omitempty"`
+
+	// Profile name.
+	Name string `json:"name,omitempty"`
+
+	// Path of the root disk image.
+	Rootfs string `json:"rootfs,omitempty"`
+}
+
+// Validate validates this boot profile
+func (m *BootProfile) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this boot profile based on context it is used
+func (m *BootProfile) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *BootProfile) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *BootProfile) UnmarshalBinary(b []byte) error {
+	var res BootProfile
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
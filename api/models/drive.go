@@ -0,0 +1,84 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Drive Additional block device of a VM, exactly one of path, sizeMib and volume must be set.
+//
+// swagger:model Drive
+type Drive struct {
+
+	// Path of a disk image in the data dir of the server, relative to it or absolute, attached read-only.
+	Path string `json:"path,omitempty"`
+
+	// Attach the persistent volume read-only.
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// Size of an empty ext4 scratch disk created for the VM and deleted when it stops,
+	// at most the maximum disk size of the server.
+	// Minimum: 1
+	SizeMib int64 `json:"sizeMib,omitempty"`
+
+	// Name of a persistent volume.
+	Volume string `json:"volume,omitempty"`
+}
+
+// Validate validates this drive
+func (m *Drive) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateSizeMib(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Drive) validateSizeMib(formats strfmt.Registry) error {
+	if swag.IsZero(m.SizeMib) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("sizeMib", "body", m.SizeMib, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this drive based on context it is used
+func (m *Drive) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Drive) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Drive) UnmarshalBinary(b []byte) error {
+	var res Drive
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,134 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Event VM lifecycle event
+//
+// swagger:model Event
+type Event struct {
+
+	// Human readable details.
+	Message string `json:"message,omitempty"`
+
+	// Name of the service the VM belongs to.
+	Service string `json:"service,omitempty"`
+
+	// Time the event was recorded.
+	// Format: date-time
+	Time strfmt.DateTime `json:"time,omitempty"`
+
+	// Event type.
+	// Enum: [hibernated restored]
+	Type string `json:"type,omitempty"`
+
+	// ID of the VM.
+	Vmid string `json:"vmid,omitempty"`
+}
+
+// Validate validates this event
+func (m *Event) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateTime(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Event) validateTime(formats strfmt.Registry) error {
+	if swag.IsZero(m.Time) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("time", "body", "date-time", m.Time.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var eventTypeTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["hibernated","restored"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		eventTypeTypePropEnum = append(eventTypeTypePropEnum, v)
+	}
+}
+
+const (
+
+	// EventTypeHibernated captures enum value "hibernated"
+	EventTypeHibernated string = "hibernated"
+
+	// EventTypeRestored captures enum value "restored"
+	EventTypeRestored string = "restored"
+)
+
+// prop value enum
+func (m *Event) validateTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, eventTypeTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Event) validateType(formats strfmt.Registry) error {
+	if swag.IsZero(m.Type) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateTypeEnum("type", "body", m.Type); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this event based on context it is used
+func (m *Event) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Event) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Event) UnmarshalBinary(b []byte) error {
+	var res Event
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
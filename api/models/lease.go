@@ -0,0 +1,83 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Lease Guest IP leased on the built-in network
+//
+// swagger:model Lease
+type Lease struct {
+
+	// Name of the bridge.
+	Bridge string `json:"bridge,omitempty"`
+
+	// Time the IP was leased.
+	// Format: date-time
+	Created strfmt.DateTime `json:"created,omitempty"`
+
+	// Guest IP.
+	IP string `json:"ip,omitempty"`
+
+	// ID of the VM owning the IP, the ID of the hibernated VM for a hibernated VM.
+	Vmid string `json:"vmid,omitempty"`
+}
+
+// Validate validates this lease
+func (m *Lease) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCreated(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Lease) validateCreated(formats strfmt.Registry) error {
+	if swag.IsZero(m.Created) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("created", "body", "date-time", m.Created.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this lease based on context it is used
+func (m *Lease) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Lease) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Lease) UnmarshalBinary(b []byte) error {
+	var res Lease
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
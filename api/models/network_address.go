@@ -0,0 +1,53 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NetworkAddress IP address of a network interface
+//
+// swagger:model NetworkAddress
+type NetworkAddress struct {
+
+	// Gateway of the subnet of the IP.
+	Gateway string `json:"gateway,omitempty"`
+
+	// Guest IP with the prefix length.
+	IP string `json:"ip,omitempty"`
+}
+
+// Validate validates this network address
+func (m *NetworkAddress) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this network address based on context it is used
+func (m *NetworkAddress) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NetworkAddress) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NetworkAddress) UnmarshalBinary(b []byte) error {
+	var res NetworkAddress
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
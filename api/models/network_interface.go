@@ -0,0 +1,134 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NetworkInterface Network interface of a VM
+//
+// swagger:model NetworkInterface
+type NetworkInterface struct {
+
+	// IPv4 and IPv6 addresses of the guest, the primary address first.
+	Addresses []*NetworkAddress `json:"addresses"`
+
+	// The MMDS is served on the interface.
+	AllowMMDS bool `json:"allowMMDS,omitempty"`
+
+	// Gateway of the primary guest IP.
+	Gateway string `json:"gateway,omitempty"`
+
+	// Name of the tap device on the host.
+	HostDevName string `json:"hostDevName,omitempty"`
+
+	// Name of the interface in the guest.
+	IfName string `json:"ifName,omitempty"`
+
+	// Primary guest IP with the prefix length, the first IPv4 address or the first IPv6 address
+	// of an IPv6 only guest.
+	IP string `json:"ip,omitempty"`
+
+	// MAC address of the guest.
+	Mac string `json:"mac,omitempty"`
+
+	// Name of the CNI network or of the bridge of the built-in network.
+	Network string `json:"network,omitempty"`
+}
+
+// Validate validates this network interface
+func (m *NetworkInterface) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAddresses(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NetworkInterface) validateAddresses(formats strfmt.Registry) error {
+	if swag.IsZero(m.Addresses) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Addresses); i++ {
+		if swag.IsZero(m.Addresses[i]) { // not required
+			continue
+		}
+
+		if m.Addresses[i] != nil {
+			if err := m.Addresses[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("addresses" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this network interface based on the context it is used
+func (m *NetworkInterface) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAddresses(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NetworkInterface) contextValidateAddresses(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Addresses); i++ {
+
+		if m.Addresses[i] != nil {
+			if err := m.Addresses[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("addresses" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NetworkInterface) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NetworkInterface) UnmarshalBinary(b []byte) error {
+	var res NetworkInterface
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,181 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// NetworkInterfaceRequest Network interface of a VM to be started
+//
+// swagger:model NetworkInterfaceRequest
+type NetworkInterfaceRequest struct {
+
+	// Serve the MMDS on the interface, requires MMDS.
+	AllowMMDS bool `json:"allowMMDS,omitempty"`
+
+	// bandwidth
+	Bandwidth *Bandwidth `json:"bandwidth,omitempty"`
+
+	// Guest IPs in CIDR notation requested from the IPAM plugin, requires CNI.
+	Ips []string `json:"ips"`
+
+	// MAC address of the guest, requires CNI.
+	Mac string `json:"mac,omitempty"`
+
+	// Name of the CNI network when CNI is enabled, otherwise the name of the bridge.
+	// Required: true
+	Network *string `json:"network"`
+
+	// Host ports forwarded to the guest by the portmap plugin, requires CNI.
+	PortMappings []*PortMapping `json:"portMappings"`
+}
+
+// Validate validates this network interface request
+func (m *NetworkInterfaceRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateBandwidth(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNetwork(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePortMappings(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NetworkInterfaceRequest) validateBandwidth(formats strfmt.Registry) error {
+	if swag.IsZero(m.Bandwidth) { // not required
+		return nil
+	}
+
+	if m.Bandwidth != nil {
+		if err := m.Bandwidth.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("bandwidth")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *NetworkInterfaceRequest) validateNetwork(formats strfmt.Registry) error {
+
+	if err := validate.Required("network", "body", m.Network); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *NetworkInterfaceRequest) validatePortMappings(formats strfmt.Registry) error {
+	if swag.IsZero(m.PortMappings) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.PortMappings); i++ {
+		if swag.IsZero(m.PortMappings[i]) { // not required
+			continue
+		}
+
+		if m.PortMappings[i] != nil {
+			if err := m.PortMappings[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("portMappings" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this network interface request based on the context it is used
+func (m *NetworkInterfaceRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateBandwidth(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidatePortMappings(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *NetworkInterfaceRequest) contextValidateBandwidth(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Bandwidth != nil {
+		if err := m.Bandwidth.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("bandwidth")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *NetworkInterfaceRequest) contextValidatePortMappings(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.PortMappings); i++ {
+
+		if m.PortMappings[i] != nil {
+			if err := m.PortMappings[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("portMappings" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NetworkInterfaceRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NetworkInterfaceRequest) UnmarshalBinary(b []byte) error {
+	var res NetworkInterfaceRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,165 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// PortMapping Host port forwarded to a guest port
+//
+// swagger:model PortMapping
+type PortMapping struct {
+
+	// guest port
+	// Required: true
+	// Maximum: 65535
+	// Minimum: 1
+	GuestPort *int64 `json:"guestPort"`
+
+	// Host IP the mapping is restricted to.
+	HostIP string `json:"hostIP,omitempty"`
+
+	// host port
+	// Required: true
+	// Maximum: 65535
+	// Minimum: 1
+	HostPort *int64 `json:"hostPort"`
+
+	// Protocol, tcp by default.
+	// Enum: [tcp udp sctp]
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// Validate validates this port mapping
+func (m *PortMapping) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateGuestPort(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateHostPort(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateProtocol(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PortMapping) validateGuestPort(formats strfmt.Registry) error {
+
+	if err := validate.Required("guestPort", "body", m.GuestPort); err != nil {
+		return err
+	}
+
+	if err := validate.MinimumInt("guestPort", "body", *m.GuestPort, 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("guestPort", "body", *m.GuestPort, 65535, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PortMapping) validateHostPort(formats strfmt.Registry) error {
+
+	if err := validate.Required("hostPort", "body", m.HostPort); err != nil {
+		return err
+	}
+
+	if err := validate.MinimumInt("hostPort", "body", *m.HostPort, 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("hostPort", "body", *m.HostPort, 65535, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var portMappingTypeProtocolPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["tcp","udp","sctp"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		portMappingTypeProtocolPropEnum = append(portMappingTypeProtocolPropEnum, v)
+	}
+}
+
+const (
+
+	// PortMappingProtocolTCP captures enum value "tcp"
+	PortMappingProtocolTCP string = "tcp"
+
+	// PortMappingProtocolUDP captures enum value "udp"
+	PortMappingProtocolUDP string = "udp"
+
+	// PortMappingProtocolSctp captures enum value "sctp"
+	PortMappingProtocolSctp string = "sctp"
+)
+
+// prop value enum
+func (m *PortMapping) validateProtocolEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, portMappingTypeProtocolPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PortMapping) validateProtocol(formats strfmt.Registry) error {
+	if swag.IsZero(m.Protocol) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateProtocolEnum("protocol", "body", m.Protocol); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this port mapping based on context it is used
+func (m *PortMapping) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PortMapping) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PortMapping) UnmarshalBinary(b []byte) error {
+	var res PortMapping
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
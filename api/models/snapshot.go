@@ -0,0 +1,86 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Snapshot Snapshot of a VM
+//
+// swagger:model Snapshot
+type Snapshot struct {
+
+	// Time the snapshot was taken.
+	// Format: date-time
+	Created strfmt.DateTime `json:"created,omitempty"`
+
+	// IP address of the VM the snapshot was taken from, the restored VMs keep it.
+	IP string `json:"ip,omitempty"`
+
+	// Snapshot name.
+	Name string `json:"name,omitempty"`
+
+	// Name of the service the VM belongs to.
+	Service string `json:"service,omitempty"`
+
+	// ID of the VM the snapshot was taken from.
+	Vmid string `json:"vmid,omitempty"`
+}
+
+// Validate validates this snapshot
+func (m *Snapshot) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCreated(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Snapshot) validateCreated(formats strfmt.Registry) error {
+	if swag.IsZero(m.Created) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("created", "body", "date-time", m.Created.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this snapshot based on context it is used
+func (m *Snapshot) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Snapshot) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Snapshot) UnmarshalBinary(b []byte) error {
+	var res Snapshot
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,76 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// SnapshotCreateRequest Snapshot specification
+//
+// swagger:model SnapshotCreateRequest
+type SnapshotCreateRequest struct {
+
+	// Snapshot name, letters, digits, '.', '_' and '-'.
+	// Required: true
+	// Pattern: ^[A-Za-z0-9][A-Za-z0-9._-]*$
+	Name *string `json:"name"`
+}
+
+// Validate validates this snapshot create request
+func (m *SnapshotCreateRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SnapshotCreateRequest) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("name", "body", m.Name); err != nil {
+		return err
+	}
+
+	if err := validate.Pattern("name", "body", *m.Name, `^[A-Za-z0-9][A-Za-z0-9._-]*$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this snapshot create request based on context it is used
+func (m *SnapshotCreateRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SnapshotCreateRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SnapshotCreateRequest) UnmarshalBinary(b []byte) error {
+	var res SnapshotCreateRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,27 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// VMMetrics Firecracker metrics of the VM by group, the counters accumulated since the VM start and the last value of the gauges.
+//
+// swagger:model VMMetrics
+type VMMetrics map[string]map[string]int64
+
+// Validate validates this VM metrics
+func (m VMMetrics) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this VM metrics based on context it is used
+func (m VMMetrics) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
@@ -0,0 +1,98 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// VolumeCreateRequest Persistent volume specification
+//
+// swagger:model VolumeCreateRequest
+type VolumeCreateRequest struct {
+
+	// Volume name, letters, digits, '.', '_' and '-'.
+	// Required: true
+	// Pattern: ^[A-Za-z0-9][A-Za-z0-9._-]*$
+	Name *string `json:"name"`
+
+	// Size of the volume, at most the maximum disk size of the server.
+	// Required: true
+	// Minimum: 1
+	SizeMib *int64 `json:"sizeMib"`
+}
+
+// Validate validates this volume create request
+func (m *VolumeCreateRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSizeMib(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *VolumeCreateRequest) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("name", "body", m.Name); err != nil {
+		return err
+	}
+
+	if err := validate.Pattern("name", "body", *m.Name, `^[A-Za-z0-9][A-Za-z0-9._-]*$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *VolumeCreateRequest) validateSizeMib(formats strfmt.Registry) error {
+
+	if err := validate.Required("sizeMib", "body", m.SizeMib); err != nil {
+		return err
+	}
+
+	if err := validate.MinimumInt("sizeMib", "body", *m.SizeMib, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this volume create request based on context it is used
+func (m *VolumeCreateRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *VolumeCreateRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *VolumeCreateRequest) UnmarshalBinary(b []byte) error {
+	var res VolumeCreateRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
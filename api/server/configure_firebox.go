@@ -13,9 +13,16 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/combust-labs/firebox/api/server/restapi"
+	"github.com/combust-labs/firebox/api/server/restapi/events"
+	"github.com/combust-labs/firebox/api/server/restapi/network"
 	"github.com/combust-labs/firebox/api/server/restapi/health"
+	"github.com/combust-labs/firebox/api/server/restapi/profile"
+	"github.com/combust-labs/firebox/api/server/restapi/secret"
 	"github.com/combust-labs/firebox/api/server/restapi/service"
+	"github.com/combust-labs/firebox/api/server/restapi/snapshot"
 	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/api/server/restapi/volume"
+	"github.com/combust-labs/firebox/pkg/metrics"
 	"github.com/combust-labs/firebox/pkg/prober"
 	"github.com/combust-labs/firebox/pkg/prober/local"
 )
@@ -52,11 +59,118 @@ func configureAPI(api *restapi.FireboxAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	api.TxtProducer = runtime.TextProducer()
+
 	if api.VMPostVMRunHandler == nil {
 		api.VMPostVMRunHandler = vm.PostVMRunHandlerFunc(func(params vm.PostVMRunParams) middleware.Responder {
 			return middleware.NotImplemented("operation vm.PostVMRun has not yet been implemented")
 		})
 	}
+	if api.VMGetVMHandler == nil {
+		api.VMGetVMHandler = vm.GetVMHandlerFunc(func(params vm.GetVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVM has not yet been implemented")
+		})
+	}
+	if api.VMGetVMMetricsHandler == nil {
+		api.VMGetVMMetricsHandler = vm.GetVMMetricsHandlerFunc(func(params vm.GetVMMetricsParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVMMetrics has not yet been implemented")
+		})
+	}
+	if api.VMGetVMLogsHandler == nil {
+		api.VMGetVMLogsHandler = vm.GetVMLogsHandlerFunc(func(params vm.GetVMLogsParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVMLogs has not yet been implemented")
+		})
+	}
+	if api.VMAttachVMConsoleHandler == nil {
+		api.VMAttachVMConsoleHandler = vm.AttachVMConsoleHandlerFunc(func(params vm.AttachVMConsoleParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.AttachVMConsole has not yet been implemented")
+		})
+	}
+	if api.VMPutVMMetadataHandler == nil {
+		api.VMPutVMMetadataHandler = vm.PutVMMetadataHandlerFunc(func(params vm.PutVMMetadataParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.PutVMMetadata has not yet been implemented")
+		})
+	}
+	if api.VMGetVMBalloonHandler == nil {
+		api.VMGetVMBalloonHandler = vm.GetVMBalloonHandlerFunc(func(params vm.GetVMBalloonParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVMBalloon has not yet been implemented")
+		})
+	}
+	if api.VMUpdateVMBalloonHandler == nil {
+		api.VMUpdateVMBalloonHandler = vm.UpdateVMBalloonHandlerFunc(func(params vm.UpdateVMBalloonParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.UpdateVMBalloon has not yet been implemented")
+		})
+	}
+	if api.VMPauseVMHandler == nil {
+		api.VMPauseVMHandler = vm.PauseVMHandlerFunc(func(params vm.PauseVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.PauseVM has not yet been implemented")
+		})
+	}
+	if api.VMResumeVMHandler == nil {
+		api.VMResumeVMHandler = vm.ResumeVMHandlerFunc(func(params vm.ResumeVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.ResumeVM has not yet been implemented")
+		})
+	}
+	if api.SnapshotCreateSnapshotHandler == nil {
+		api.SnapshotCreateSnapshotHandler = snapshot.CreateSnapshotHandlerFunc(func(params snapshot.CreateSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation snapshot.CreateSnapshot has not yet been implemented")
+		})
+	}
+	if api.SnapshotListSnapshotsHandler == nil {
+		api.SnapshotListSnapshotsHandler = snapshot.ListSnapshotsHandlerFunc(func(params snapshot.ListSnapshotsParams) middleware.Responder {
+			return middleware.NotImplemented("operation snapshot.ListSnapshots has not yet been implemented")
+		})
+	}
+	if api.SnapshotDeleteSnapshotHandler == nil {
+		api.SnapshotDeleteSnapshotHandler = snapshot.DeleteSnapshotHandlerFunc(func(params snapshot.DeleteSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation snapshot.DeleteSnapshot has not yet been implemented")
+		})
+	}
+	if api.ProfileListProfilesHandler == nil {
+		api.ProfileListProfilesHandler = profile.ListProfilesHandlerFunc(func(params profile.ListProfilesParams) middleware.Responder {
+			return middleware.NotImplemented("operation profile.ListProfiles has not yet been implemented")
+		})
+	}
+	if api.SecretListSecretsHandler == nil {
+		api.SecretListSecretsHandler = secret.ListSecretsHandlerFunc(func(params secret.ListSecretsParams) middleware.Responder {
+			return middleware.NotImplemented("operation secret.ListSecrets has not yet been implemented")
+		})
+	}
+	if api.SecretPutSecretHandler == nil {
+		api.SecretPutSecretHandler = secret.PutSecretHandlerFunc(func(params secret.PutSecretParams) middleware.Responder {
+			return middleware.NotImplemented("operation secret.PutSecret has not yet been implemented")
+		})
+	}
+	if api.SecretDeleteSecretHandler == nil {
+		api.SecretDeleteSecretHandler = secret.DeleteSecretHandlerFunc(func(params secret.DeleteSecretParams) middleware.Responder {
+			return middleware.NotImplemented("operation secret.DeleteSecret has not yet been implemented")
+		})
+	}
+	if api.VolumeListVolumesHandler == nil {
+		api.VolumeListVolumesHandler = volume.ListVolumesHandlerFunc(func(params volume.ListVolumesParams) middleware.Responder {
+			return middleware.NotImplemented("operation volume.ListVolumes has not yet been implemented")
+		})
+	}
+	if api.VolumeCreateVolumeHandler == nil {
+		api.VolumeCreateVolumeHandler = volume.CreateVolumeHandlerFunc(func(params volume.CreateVolumeParams) middleware.Responder {
+			return middleware.NotImplemented("operation volume.CreateVolume has not yet been implemented")
+		})
+	}
+	if api.VolumeDeleteVolumeHandler == nil {
+		api.VolumeDeleteVolumeHandler = volume.DeleteVolumeHandlerFunc(func(params volume.DeleteVolumeParams) middleware.Responder {
+			return middleware.NotImplemented("operation volume.DeleteVolume has not yet been implemented")
+		})
+	}
+	if api.EventsListEventsHandler == nil {
+		api.EventsListEventsHandler = events.ListEventsHandlerFunc(func(params events.ListEventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation events.ListEvents has not yet been implemented")
+		})
+	}
+	if api.NetworkListLeasesHandler == nil {
+		api.NetworkListLeasesHandler = network.ListLeasesHandlerFunc(func(params network.ListLeasesParams) middleware.Responder {
+			return middleware.NotImplemented("operation network.ListLeases has not yet been implemented")
+		})
+	}
 	if api.ServiceInvokeHandler == nil {
 		api.ServiceInvokeHandler = service.InvokeHandlerFunc(func(params service.InvokeParams) middleware.Responder {
 			return middleware.NotImplemented("operation service.Invoke has not yet been implemented")
@@ -111,5 +225,8 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics.
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	return handler
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/", handler)
+	return mux
 }
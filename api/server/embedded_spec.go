@@ -102,6 +102,34 @@ func init() {
         }
       }
     },
+    "/events": {
+      "get": {
+        "description": "This endpoint lists the latest VM lifecycle events, the oldest first.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "listEvents",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Return only the events of the service.",
+            "name": "service",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Event"
+              }
+            }
+          }
+        }
+      }
+    },
     "/invoke": {
       "post": {
         "description": "Invoke test service.",
@@ -110,6 +138,13 @@ func init() {
         ],
         "operationId": "invoke",
         "parameters": [
+          {
+            "type": "string",
+            "default": "default",
+            "description": "Name of the service to invoke.",
+            "name": "service",
+            "in": "query"
+          },
           {
             "name": "data",
             "in": "body",
@@ -141,17 +176,21 @@ func init() {
         }
       }
     },
-    "/vm/run": {
-      "post": {
-        "description": "This endpoint creates a new VM and starts it",
+    "/network/leases": {
+      "get": {
+        "description": "This endpoint lists the guest IPs leased on the bridges of the built-in network, the list is empty when CNI is enabled.",
         "tags": [
-          "vm"
+          "network"
         ],
+        "operationId": "listLeases",
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Lease"
+              }
             }
           },
           "500": {
@@ -162,250 +201,1576 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "HTTPRequest": {
-      "type": "object",
-      "properties": {
-        "body": {
-          "type": "string",
-          "x-omitempty": true
-        },
-        "cookies": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          },
-          "x-omitempty": true
-        },
-        "headers": {
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
-          },
-          "x-omitempty": false
-        },
-        "httpMethod": {
-          "type": "string",
-          "x-omitempty": false
-        },
-        "isBase64Encoded": {
-          "description": "A boolean flag to indicate if the body is Base64-encoded",
-          "type": "boolean",
-          "x-omitempty": false
-        },
-        "multiValueHeaders": {
-          "type": "object",
-          "additionalProperties": {
-            "type": "array",
-            "items": {
-              "type": "string"
-            }
-          },
-          "x-omitempty": false
-        },
-        "rawPath": {
-          "description": "encoded path hint (see EscapedPath method)",
-          "type": "string",
-          "x-omitempty": false
-        },
-        "rawQueryString": {
-          "description": "encoded query values, without '?'",
-          "type": "string",
-          "x-omitempty": false
-        }
-      }
     },
-    "HTTPResponse": {
-      "type": "object",
-      "properties": {
-        "body": {
-          "type": "string",
-          "x-omitempty": true
-        },
-        "cookies": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          },
-          "x-omitempty": true
-        },
-        "headers": {
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
-          },
-          "x-omitempty": false
-        },
-        "isBase64Encoded": {
-          "description": "A boolean flag to indicate if the body is Base64-encoded",
-          "type": "boolean",
-          "x-omitempty": false
-        },
-        "multiValueHeaders": {
-          "type": "object",
-          "additionalProperties": {
-            "type": "array",
-            "items": {
-              "type": "string"
+    "/profiles": {
+      "get": {
+        "description": "This endpoint lists the boot profiles.",
+        "tags": [
+          "profile"
+        ],
+        "operationId": "listProfiles",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/BootProfile"
+              }
             }
-          },
-          "x-omitempty": true
-        },
-        "statusCode": {
-          "type": "integer"
-        }
-      }
-    },
-    "HealthHealthy": {
-      "type": "object",
-      "properties": {
-        "details": {
-          "type": "array",
-          "items": {
-            "type": "object",
-            "additionalProperties": true
-          }
-        },
-        "status": {
-          "description": "Status always contains \"OK\".",
-          "type": "string"
-        }
-      }
-    },
-    "HealthServiceUnavailable": {
-      "type": "object",
-      "properties": {
-        "errors": {
-          "description": "Errors contains a list of errors that caused the not ready status.",
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
-          }
-        },
-        "status": {
-          "description": "Status always contains \"NOT OK\".",
-          "type": "string"
-        }
-      }
-    },
-    "StandardError": {
-      "type": "object",
-      "properties": {
-        "code": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "details": {
-          "type": "array",
-          "items": {
-            "type": "object",
-            "additionalProperties": true
           }
-        },
-        "message": {
-          "type": "string"
         }
       }
     },
-    "VM": {
-      "description": "Virtual Machine",
-      "type": "object",
-      "properties": {
-        "id": {
-          "description": "Virtual Machine ID.",
-          "type": "string"
-        },
-        "ip": {
-          "description": "IP address of VM",
-          "type": "string"
-        }
-      }
-    }
-  },
-  "x-schemes": [
-    "unix"
-  ]
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http",
-    "https"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "Firebox",
-    "title": "firebox",
-    "version": "Latest"
-  },
-  "basePath": "/",
-  "paths": {
-    "/-/healthy": {
+    "/secrets": {
       "get": {
-        "description": "This endpoint returns a 200 status code when the HTTP server is up running.",
-        "produces": [
-          "application/json"
-        ],
+        "description": "This endpoint lists the secrets, the values are never returned.",
         "tags": [
-          "health"
+          "secret"
         ],
-        "summary": "Check health status",
-        "operationId": "isHealthy",
+        "operationId": "listSecrets",
         "responses": {
           "200": {
-            "description": "healthStatus",
+            "description": "Success",
             "schema": {
-              "$ref": "#/definitions/HealthHealthy"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Secret"
+              }
             }
           },
           "500": {
-            "description": "The standard error format",
+            "description": "Internal Server Error",
             "schema": {
               "$ref": "#/definitions/StandardError"
             }
-          },
-          "503": {
-            "description": "healthNotHealthyStatus",
-            "schema": {
-              "$ref": "#/definitions/HealthServiceUnavailable"
-            }
           }
         }
       }
     },
-    "/-/ready": {
-      "get": {
-        "description": "This endpoint returns a 200 status code when the HTTP server is up running and the environment dependencies are responsive as well.",
-        "produces": [
-          "application/json"
-        ],
+    "/secrets/{name}": {
+      "put": {
+        "description": "This endpoint creates or rotates a secret. The running VMs receiving their environment through the MMDS get the new value.",
         "tags": [
-          "health"
+          "secret"
+        ],
+        "operationId": "putSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Secret name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "data",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SecretPutRequest"
+            }
+          }
         ],
-        "summary": "Check readiness status",
-        "operationId": "isReady",
         "responses": {
           "200": {
-            "description": "healthStatus",
+            "description": "Stored",
             "schema": {
-              "$ref": "#/definitions/HealthHealthy"
+              "$ref": "#/definitions/Secret"
             }
           },
-          "500": {
-            "description": "The standard error format",
+          "400": {
+            "description": "Bad Request",
             "schema": {
               "$ref": "#/definitions/StandardError"
             }
           },
-          "503": {
-            "description": "healthNotReadyStatus",
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "This endpoint deletes a secret, the running VMs keep its value.",
+        "tags": [
+          "secret"
+        ],
+        "operationId": "deleteSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Secret name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/snapshots": {
+      "get": {
+        "description": "This endpoint lists the snapshots.",
+        "tags": [
+          "snapshot"
+        ],
+        "operationId": "listSnapshots",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Snapshot"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/snapshots/{name}": {
+      "delete": {
+        "description": "This endpoint deletes a snapshot, the VMs started from it keep running.",
+        "tags": [
+          "snapshot"
+        ],
+        "operationId": "deleteSnapshot",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Snapshot name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/run": {
+      "post": {
+        "description": "This endpoint creates a new VM and starts it",
+        "tags": [
+          "vm"
+        ],
+        "parameters": [
+          {
+            "name": "spec",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/VMRunRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}": {
+      "get": {
+        "description": "This endpoint returns the VM with its network interfaces.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVm",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/balloon": {
+      "get": {
+        "description": "This endpoint returns the balloon size and the guest memory statistics of the VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVmBalloon",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Balloon"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "patch": {
+        "description": "This endpoint inflates or deflates the balloon of the VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "updateVmBalloon",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "data",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/BalloonUpdateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Updated"
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/console": {
+      "get": {
+        "description": "This endpoint upgrades the connection to a WebSocket attached to the serial console of the VM.\nBinary or text messages sent by the client are written to the console input,\nthe console output is sent to the client as binary messages.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "attachVmConsole",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "101": {
+            "description": "Switching Protocols"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/logs": {
+      "get": {
+        "description": "This endpoint returns the serial console output of the VM.",
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVmLogs",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep streaming the console output until the VM stops or the client disconnects.",
+            "name": "follow",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": -1,
+            "description": "Number of lines to return from the end of the buffered output, all buffered lines if negative.",
+            "name": "tail",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/metadata": {
+      "put": {
+        "description": "This endpoint replaces the metadata JSON of the VM in the MMDS, the standard keys under \"firebox\" are kept.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "putVmMetadata",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "data",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Updated"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/metrics": {
+      "get": {
+        "description": "This endpoint returns the Firecracker metrics of the VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVmMetrics",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/VMMetrics"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/pause": {
+      "post": {
+        "description": "This endpoint pauses the VM keeping its state. A paused VM does not serve invocations and is not probed.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "pauseVm",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Paused"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/resume": {
+      "post": {
+        "description": "This endpoint resumes a paused VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "resumeVm",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Resumed"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/snapshot": {
+      "post": {
+        "description": "This endpoint pauses the VM, stores its memory, device state and drives into a named snapshot and resumes it.\nNew VMs can be started from the snapshot instead of booting the kernel.",
+        "tags": [
+          "snapshot"
+        ],
+        "operationId": "createSnapshot",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "spec",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SnapshotCreateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "$ref": "#/definitions/Snapshot"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/volumes": {
+      "get": {
+        "description": "This endpoint lists the persistent volumes.",
+        "tags": [
+          "volume"
+        ],
+        "operationId": "listVolumes",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Volume"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "This endpoint creates a new persistent volume formatted with ext4.\nThe volume outlives the VMs it is attached to until it is deleted.",
+        "tags": [
+          "volume"
+        ],
+        "operationId": "createVolume",
+        "parameters": [
+          {
+            "name": "spec",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/VolumeCreateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "$ref": "#/definitions/Volume"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/volumes/{name}": {
+      "delete": {
+        "description": "This endpoint deletes a persistent volume which is not attached to a VM.",
+        "tags": [
+          "volume"
+        ],
+        "operationId": "deleteVolume",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Volume name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Balloon": {
+      "description": "Memory balloon of a VM, the guest memory statistics are in bytes",
+      "type": "object",
+      "properties": {
+        "actualMib": {
+          "description": "Size of the balloon the guest holds.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "availableMemory": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "diskCaches": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "freeMemory": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "majorFaults": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "minorFaults": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "swapIn": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "swapOut": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "targetMib": {
+          "description": "Size of the balloon the guest is requested to hold.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "totalMemory": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "BalloonUpdateRequest": {
+      "description": "Balloon size",
+      "type": "object",
+      "required": [
+        "amountMib"
+      ],
+      "properties": {
+        "amountMib": {
+          "description": "Size of the balloon in MiB.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "Bandwidth": {
+      "description": "Traffic limits of a network interface applied by the bandwidth plugin, requires CNI.\nA rate and its burst must be set together.",
+      "type": "object",
+      "properties": {
+        "egressBurst": {
+          "description": "Egress burst in bits.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "egressRate": {
+          "description": "Egress rate in bits per second.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "ingressBurst": {
+          "description": "Ingress burst in bits.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "ingressRate": {
+          "description": "Ingress rate in bits per second.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "BootProfile": {
+      "description": "Named boot configuration, the empty fields keep the configuration of the server",
+      "type": "object",
+      "properties": {
+        "initrd": {
+          "description": "Path of the initrd image.",
+          "type": "string"
+        },
+        "kernelArgs": {
+          "description": "Kernel command line.",
+          "type": "string"
+        },
+        "kernelImage": {
+          "description": "Path of the kernel image.",
+          "type": "string"
+        },
+        "name": {
+          "description": "Profile name.",
+          "type": "string"
+        },
+        "rootfs": {
+          "description": "Path of the root disk image.",
+          "type": "string"
+        }
+      }
+    },
+    "Condition": {
+      "description": "Condition of a VM",
+      "type": "object",
+      "required": [
+        "type",
+        "status"
+      ],
+      "properties": {
+        "lastTransitionTime": {
+          "description": "Time the status changed.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "message": {
+          "description": "Reason of the false status.",
+          "type": "string"
+        },
+        "status": {
+          "type": "boolean"
+        },
+        "type": {
+          "description": "Type of the condition. Ready is true while the health probe succeeds,\nNetworkReady is false while the check of the network attachments fails.",
+          "type": "string"
+        }
+      }
+    },
+    "Drive": {
+      "description": "Additional block device of a VM, exactly one of path, sizeMib and volume must be set.",
+      "type": "object",
+      "properties": {
+        "path": {
+          "description": "Path of a disk image in the data dir of the server, relative to it or absolute, attached read-only.",
+          "type": "string"
+        },
+        "readOnly": {
+          "description": "Attach the persistent volume read-only.",
+          "type": "boolean"
+        },
+        "sizeMib": {
+          "description": "Size of an empty ext4 scratch disk created for the VM and deleted when it stops,\nat most the maximum disk size of the server.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "volume": {
+          "description": "Name of a persistent volume.",
+          "type": "string"
+        }
+      }
+    },
+    "Event": {
+      "description": "VM lifecycle event",
+      "type": "object",
+      "properties": {
+        "message": {
+          "description": "Human readable details.",
+          "type": "string"
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        },
+        "time": {
+          "description": "Time the event was recorded.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "type": {
+          "description": "Event type.",
+          "type": "string",
+          "enum": [
+            "hibernated",
+            "restored"
+          ]
+        },
+        "vmid": {
+          "description": "ID of the VM.",
+          "type": "string"
+        }
+      }
+    },
+    "HTTPRequest": {
+      "type": "object",
+      "properties": {
+        "body": {
+          "type": "string",
+          "x-omitempty": true
+        },
+        "cookies": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "headers": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "x-omitempty": false
+        },
+        "httpMethod": {
+          "type": "string",
+          "x-omitempty": false
+        },
+        "isBase64Encoded": {
+          "description": "A boolean flag to indicate if the body is Base64-encoded",
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "multiValueHeaders": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          },
+          "x-omitempty": false
+        },
+        "rawPath": {
+          "description": "encoded path hint (see EscapedPath method)",
+          "type": "string",
+          "x-omitempty": false
+        },
+        "rawQueryString": {
+          "description": "encoded query values, without '?'",
+          "type": "string",
+          "x-omitempty": false
+        }
+      }
+    },
+    "HTTPResponse": {
+      "type": "object",
+      "properties": {
+        "body": {
+          "type": "string",
+          "x-omitempty": true
+        },
+        "cookies": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        },
+        "headers": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "x-omitempty": false
+        },
+        "isBase64Encoded": {
+          "description": "A boolean flag to indicate if the body is Base64-encoded",
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "multiValueHeaders": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          },
+          "x-omitempty": true
+        },
+        "statusCode": {
+          "type": "integer"
+        }
+      }
+    },
+    "HealthHealthy": {
+      "type": "object",
+      "properties": {
+        "details": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "additionalProperties": true
+          }
+        },
+        "status": {
+          "description": "Status always contains \"OK\".",
+          "type": "string"
+        }
+      }
+    },
+    "HealthServiceUnavailable": {
+      "type": "object",
+      "properties": {
+        "errors": {
+          "description": "Errors contains a list of errors that caused the not ready status.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "status": {
+          "description": "Status always contains \"NOT OK\".",
+          "type": "string"
+        }
+      }
+    },
+    "Lease": {
+      "description": "Guest IP leased on the built-in network",
+      "type": "object",
+      "properties": {
+        "bridge": {
+          "description": "Name of the bridge.",
+          "type": "string"
+        },
+        "created": {
+          "description": "Time the IP was leased.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "ip": {
+          "description": "Guest IP.",
+          "type": "string"
+        },
+        "vmid": {
+          "description": "ID of the VM owning the IP, the ID of the hibernated VM for a hibernated VM.",
+          "type": "string"
+        }
+      }
+    },
+    "NetworkAddress": {
+      "description": "IP address of a network interface",
+      "type": "object",
+      "properties": {
+        "gateway": {
+          "description": "Gateway of the subnet of the IP.",
+          "type": "string"
+        },
+        "ip": {
+          "description": "Guest IP with the prefix length.",
+          "type": "string"
+        }
+      }
+    },
+    "NetworkInterface": {
+      "description": "Network interface of a VM",
+      "type": "object",
+      "properties": {
+        "addresses": {
+          "description": "IPv4 and IPv6 addresses of the guest, the primary address first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NetworkAddress"
+          }
+        },
+        "allowMMDS": {
+          "description": "The MMDS is served on the interface.",
+          "type": "boolean"
+        },
+        "gateway": {
+          "description": "Gateway of the primary guest IP.",
+          "type": "string"
+        },
+        "hostDevName": {
+          "description": "Name of the tap device on the host.",
+          "type": "string"
+        },
+        "ifName": {
+          "description": "Name of the interface in the guest.",
+          "type": "string"
+        },
+        "ip": {
+          "description": "Primary guest IP with the prefix length, the first IPv4 address or the first IPv6 address\nof an IPv6 only guest.",
+          "type": "string"
+        },
+        "mac": {
+          "description": "MAC address of the guest.",
+          "type": "string"
+        },
+        "network": {
+          "description": "Name of the CNI network or of the bridge of the built-in network.",
+          "type": "string"
+        }
+      }
+    },
+    "NetworkInterfaceRequest": {
+      "description": "Network interface of a VM to be started",
+      "type": "object",
+      "required": [
+        "network"
+      ],
+      "properties": {
+        "allowMMDS": {
+          "description": "Serve the MMDS on the interface, requires MMDS.",
+          "type": "boolean"
+        },
+        "bandwidth": {
+          "$ref": "#/definitions/Bandwidth"
+        },
+        "ips": {
+          "description": "Guest IPs in CIDR notation requested from the IPAM plugin, requires CNI.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "mac": {
+          "description": "MAC address of the guest, requires CNI.",
+          "type": "string"
+        },
+        "network": {
+          "description": "Name of the CNI network when CNI is enabled, otherwise the name of the bridge.",
+          "type": "string"
+        },
+        "portMappings": {
+          "description": "Host ports forwarded to the guest by the portmap plugin, requires CNI.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PortMapping"
+          }
+        }
+      }
+    },
+    "PortMapping": {
+      "description": "Host port forwarded to a guest port",
+      "type": "object",
+      "required": [
+        "hostPort",
+        "guestPort"
+      ],
+      "properties": {
+        "guestPort": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 65535,
+          "minimum": 1
+        },
+        "hostIP": {
+          "description": "Host IP the mapping is restricted to.",
+          "type": "string"
+        },
+        "hostPort": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 65535,
+          "minimum": 1
+        },
+        "protocol": {
+          "description": "Protocol, tcp by default.",
+          "type": "string",
+          "enum": [
+            "tcp",
+            "udp",
+            "sctp"
+          ]
+        }
+      }
+    },
+    "Secret": {
+      "description": "Secret without its value",
+      "type": "object",
+      "properties": {
+        "name": {
+          "description": "Secret name.",
+          "type": "string"
+        },
+        "updated": {
+          "description": "Time the secret was last stored.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "version": {
+          "description": "Version of the secret, incremented on every rotation.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "SecretPutRequest": {
+      "description": "Secret value",
+      "type": "object",
+      "required": [
+        "value"
+      ],
+      "properties": {
+        "value": {
+          "description": "Secret value.",
+          "type": "string"
+        }
+      }
+    },
+    "Snapshot": {
+      "description": "Snapshot of a VM",
+      "type": "object",
+      "properties": {
+        "created": {
+          "description": "Time the snapshot was taken.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "ip": {
+          "description": "IP address of the VM the snapshot was taken from, the restored VMs keep it.",
+          "type": "string"
+        },
+        "name": {
+          "description": "Snapshot name.",
+          "type": "string"
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        },
+        "vmid": {
+          "description": "ID of the VM the snapshot was taken from.",
+          "type": "string"
+        }
+      }
+    },
+    "SnapshotCreateRequest": {
+      "description": "Snapshot specification",
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "description": "Snapshot name, letters, digits, '.', '_' and '-'.",
+          "type": "string",
+          "pattern": "^[A-Za-z0-9][A-Za-z0-9._-]*$"
+        }
+      }
+    },
+    "StandardError": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "details": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "additionalProperties": true
+          }
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "VM": {
+      "description": "Virtual Machine",
+      "type": "object",
+      "properties": {
+        "conditions": {
+          "description": "Conditions of the VM, Ready and NetworkReady.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Condition"
+          }
+        },
+        "id": {
+          "description": "Virtual Machine ID.",
+          "type": "string"
+        },
+        "interfaces": {
+          "description": "Network interfaces of the VM in the guest order.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NetworkInterface"
+          }
+        },
+        "ip": {
+          "description": "Primary IP address of the first network interface of the VM.",
+          "type": "string"
+        },
+        "ips": {
+          "description": "IPv4 and IPv6 addresses of the first network interface of the VM, the primary address first.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "labels": {
+          "description": "Labels of the VM.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        }
+      }
+    },
+    "VMMetrics": {
+      "description": "Firecracker metrics of the VM by group, the counters accumulated since the VM start and the last value of the gauges.",
+      "type": "object",
+      "additionalProperties": {
+        "type": "object",
+        "additionalProperties": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "VMRunRequest": {
+      "description": "Virtual Machine run specification",
+      "type": "object",
+      "properties": {
+        "drives": {
+          "description": "Additional block devices attached after the root drive, in order.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Drive"
+          }
+        },
+        "interfaces": {
+          "description": "Network interfaces of the VM in the guest order, replacing the interfaces of the service.\nA VM started from a snapshot has the interfaces of the snapshot.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NetworkInterfaceRequest"
+          }
+        },
+        "labels": {
+          "description": "Labels of the VM, added to the labels of the service.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "metadata": {
+          "description": "Metadata JSON the guest reads from the MMDS under the key \"metadata\", requires MMDS.",
+          "type": "object"
+        },
+        "profile": {
+          "description": "Name of the boot profile, defaults to the profile of the service.",
+          "type": "string"
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        },
+        "snapshot": {
+          "description": "Name of the snapshot the VM is started from instead of booting the kernel.\nThe drives and the service are taken from the snapshot unless the service is given.",
+          "type": "string"
+        }
+      }
+    },
+    "Volume": {
+      "description": "Persistent volume",
+      "type": "object",
+      "properties": {
+        "attachedTo": {
+          "description": "ID of the VM the volume is attached to, empty when detached.",
+          "type": "string"
+        },
+        "name": {
+          "description": "Volume name.",
+          "type": "string"
+        },
+        "sizeMib": {
+          "description": "Size of the volume.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "VolumeCreateRequest": {
+      "description": "Persistent volume specification",
+      "type": "object",
+      "required": [
+        "name",
+        "sizeMib"
+      ],
+      "properties": {
+        "name": {
+          "description": "Volume name, letters, digits, '.', '_' and '-'.",
+          "type": "string",
+          "pattern": "^[A-Za-z0-9][A-Za-z0-9._-]*$"
+        },
+        "sizeMib": {
+          "description": "Size of the volume, at most the maximum disk size of the server.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        }
+      }
+    }
+  },
+  "x-schemes": [
+    "unix"
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http",
+    "https"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "Firebox",
+    "title": "firebox",
+    "version": "Latest"
+  },
+  "basePath": "/",
+  "paths": {
+    "/-/healthy": {
+      "get": {
+        "description": "This endpoint returns a 200 status code when the HTTP server is up running.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "health"
+        ],
+        "summary": "Check health status",
+        "operationId": "isHealthy",
+        "responses": {
+          "200": {
+            "description": "healthStatus",
+            "schema": {
+              "$ref": "#/definitions/HealthHealthy"
+            }
+          },
+          "500": {
+            "description": "The standard error format",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "503": {
+            "description": "healthNotHealthyStatus",
+            "schema": {
+              "$ref": "#/definitions/HealthServiceUnavailable"
+            }
+          }
+        }
+      }
+    },
+    "/-/ready": {
+      "get": {
+        "description": "This endpoint returns a 200 status code when the HTTP server is up running and the environment dependencies are responsive as well.",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "health"
+        ],
+        "summary": "Check readiness status",
+        "operationId": "isReady",
+        "responses": {
+          "200": {
+            "description": "healthStatus",
+            "schema": {
+              "$ref": "#/definitions/HealthHealthy"
+            }
+          },
+          "500": {
+            "description": "The standard error format",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "503": {
+            "description": "healthNotReadyStatus",
             "schema": {
               "$ref": "#/definitions/HealthServiceUnavailable"
             }
@@ -413,69 +1778,1088 @@ func init() {
         }
       }
     },
-    "/invoke": {
-      "post": {
-        "description": "Invoke test service.",
-        "tags": [
-          "service"
-        ],
-        "operationId": "invoke",
-        "parameters": [
-          {
-            "name": "data",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/HTTPRequest"
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Success",
-            "schema": {
-              "$ref": "#/definitions/HTTPResponse"
-            }
-          },
-          "500": {
-            "description": "Internal Server Error",
-            "schema": {
-              "$ref": "#/definitions/StandardError"
-            }
-          },
-          "503": {
-            "description": "Service Unavailable",
-            "schema": {
-              "$ref": "#/definitions/StandardError"
-            }
-          }
+    "/events": {
+      "get": {
+        "description": "This endpoint lists the latest VM lifecycle events, the oldest first.",
+        "tags": [
+          "events"
+        ],
+        "operationId": "listEvents",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Return only the events of the service.",
+            "name": "service",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Event"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/invoke": {
+      "post": {
+        "description": "Invoke test service.",
+        "tags": [
+          "service"
+        ],
+        "operationId": "invoke",
+        "parameters": [
+          {
+            "type": "string",
+            "default": "default",
+            "description": "Name of the service to invoke.",
+            "name": "service",
+            "in": "query"
+          },
+          {
+            "name": "data",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/HTTPRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/HTTPResponse"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "503": {
+            "description": "Service Unavailable",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/network/leases": {
+      "get": {
+        "description": "This endpoint lists the guest IPs leased on the bridges of the built-in network, the list is empty when CNI is enabled.",
+        "tags": [
+          "network"
+        ],
+        "operationId": "listLeases",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Lease"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/profiles": {
+      "get": {
+        "description": "This endpoint lists the boot profiles.",
+        "tags": [
+          "profile"
+        ],
+        "operationId": "listProfiles",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/BootProfile"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/secrets": {
+      "get": {
+        "description": "This endpoint lists the secrets, the values are never returned.",
+        "tags": [
+          "secret"
+        ],
+        "operationId": "listSecrets",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Secret"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/secrets/{name}": {
+      "put": {
+        "description": "This endpoint creates or rotates a secret. The running VMs receiving their environment through the MMDS get the new value.",
+        "tags": [
+          "secret"
+        ],
+        "operationId": "putSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Secret name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "data",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SecretPutRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Stored",
+            "schema": {
+              "$ref": "#/definitions/Secret"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "This endpoint deletes a secret, the running VMs keep its value.",
+        "tags": [
+          "secret"
+        ],
+        "operationId": "deleteSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Secret name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/snapshots": {
+      "get": {
+        "description": "This endpoint lists the snapshots.",
+        "tags": [
+          "snapshot"
+        ],
+        "operationId": "listSnapshots",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Snapshot"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/snapshots/{name}": {
+      "delete": {
+        "description": "This endpoint deletes a snapshot, the VMs started from it keep running.",
+        "tags": [
+          "snapshot"
+        ],
+        "operationId": "deleteSnapshot",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Snapshot name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/run": {
+      "post": {
+        "description": "This endpoint creates a new VM and starts it",
+        "tags": [
+          "vm"
+        ],
+        "parameters": [
+          {
+            "name": "spec",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/VMRunRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}": {
+      "get": {
+        "description": "This endpoint returns the VM with its network interfaces.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVm",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/balloon": {
+      "get": {
+        "description": "This endpoint returns the balloon size and the guest memory statistics of the VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVmBalloon",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Balloon"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "patch": {
+        "description": "This endpoint inflates or deflates the balloon of the VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "updateVmBalloon",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "data",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/BalloonUpdateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Updated"
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/console": {
+      "get": {
+        "description": "This endpoint upgrades the connection to a WebSocket attached to the serial console of the VM.\nBinary or text messages sent by the client are written to the console input,\nthe console output is sent to the client as binary messages.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "attachVmConsole",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "101": {
+            "description": "Switching Protocols"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/logs": {
+      "get": {
+        "description": "This endpoint returns the serial console output of the VM.",
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVmLogs",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep streaming the console output until the VM stops or the client disconnects.",
+            "name": "follow",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": -1,
+            "description": "Number of lines to return from the end of the buffered output, all buffered lines if negative.",
+            "name": "tail",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/metadata": {
+      "put": {
+        "description": "This endpoint replaces the metadata JSON of the VM in the MMDS, the standard keys under \"firebox\" are kept.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "putVmMetadata",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "data",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Updated"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/metrics": {
+      "get": {
+        "description": "This endpoint returns the Firecracker metrics of the VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "getVmMetrics",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/VMMetrics"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/pause": {
+      "post": {
+        "description": "This endpoint pauses the VM keeping its state. A paused VM does not serve invocations and is not probed.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "pauseVm",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Paused"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/resume": {
+      "post": {
+        "description": "This endpoint resumes a paused VM.",
+        "tags": [
+          "vm"
+        ],
+        "operationId": "resumeVm",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Resumed"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/vm/{id}/snapshot": {
+      "post": {
+        "description": "This endpoint pauses the VM, stores its memory, device state and drives into a named snapshot and resumes it.\nNew VMs can be started from the snapshot instead of booting the kernel.",
+        "tags": [
+          "snapshot"
+        ],
+        "operationId": "createSnapshot",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Virtual Machine ID.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "spec",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SnapshotCreateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "$ref": "#/definitions/Snapshot"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/volumes": {
+      "get": {
+        "description": "This endpoint lists the persistent volumes.",
+        "tags": [
+          "volume"
+        ],
+        "operationId": "listVolumes",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Volume"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "This endpoint creates a new persistent volume formatted with ext4.\nThe volume outlives the VMs it is attached to until it is deleted.",
+        "tags": [
+          "volume"
+        ],
+        "operationId": "createVolume",
+        "parameters": [
+          {
+            "name": "spec",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/VolumeCreateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Created",
+            "schema": {
+              "$ref": "#/definitions/Volume"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/volumes/{name}": {
+      "delete": {
+        "description": "This endpoint deletes a persistent volume which is not attached to a VM.",
+        "tags": [
+          "volume"
+        ],
+        "operationId": "deleteVolume",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Volume name.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted"
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "409": {
+            "description": "Conflict",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "500": {
+            "description": "Internal Server Error",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Balloon": {
+      "description": "Memory balloon of a VM, the guest memory statistics are in bytes",
+      "type": "object",
+      "properties": {
+        "actualMib": {
+          "description": "Size of the balloon the guest holds.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "availableMemory": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "diskCaches": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "freeMemory": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "majorFaults": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "minorFaults": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "swapIn": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "swapOut": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "targetMib": {
+          "description": "Size of the balloon the guest is requested to hold.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "totalMemory": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "BalloonUpdateRequest": {
+      "description": "Balloon size",
+      "type": "object",
+      "required": [
+        "amountMib"
+      ],
+      "properties": {
+        "amountMib": {
+          "description": "Size of the balloon in MiB.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        }
+      }
+    },
+    "Bandwidth": {
+      "description": "Traffic limits of a network interface applied by the bandwidth plugin, requires CNI.\nA rate and its burst must be set together.",
+      "type": "object",
+      "properties": {
+        "egressBurst": {
+          "description": "Egress burst in bits.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        },
+        "egressRate": {
+          "description": "Egress rate in bits per second.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        },
+        "ingressBurst": {
+          "description": "Ingress burst in bits.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        },
+        "ingressRate": {
+          "description": "Ingress rate in bits per second.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        }
+      }
+    },
+    "BootProfile": {
+      "description": "Named boot configuration, the empty fields keep the configuration of the server",
+      "type": "object",
+      "properties": {
+        "initrd": {
+          "description": "Path of the initrd image.",
+          "type": "string"
+        },
+        "kernelArgs": {
+          "description": "Kernel command line.",
+          "type": "string"
+        },
+        "kernelImage": {
+          "description": "Path of the kernel image.",
+          "type": "string"
+        },
+        "name": {
+          "description": "Profile name.",
+          "type": "string"
+        },
+        "rootfs": {
+          "description": "Path of the root disk image.",
+          "type": "string"
+        }
+      }
+    },
+    "Condition": {
+      "description": "Condition of a VM",
+      "type": "object",
+      "required": [
+        "type",
+        "status"
+      ],
+      "properties": {
+        "lastTransitionTime": {
+          "description": "Time the status changed.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "message": {
+          "description": "Reason of the false status.",
+          "type": "string"
+        },
+        "status": {
+          "type": "boolean"
+        },
+        "type": {
+          "description": "Type of the condition. Ready is true while the health probe succeeds,\nNetworkReady is false while the check of the network attachments fails.",
+          "type": "string"
+        }
+      }
+    },
+    "Drive": {
+      "description": "Additional block device of a VM, exactly one of path, sizeMib and volume must be set.",
+      "type": "object",
+      "properties": {
+        "path": {
+          "description": "Path of a disk image in the data dir of the server, relative to it or absolute, attached read-only.",
+          "type": "string"
+        },
+        "readOnly": {
+          "description": "Attach the persistent volume read-only.",
+          "type": "boolean"
+        },
+        "sizeMib": {
+          "description": "Size of an empty ext4 scratch disk created for the VM and deleted when it stops,\nat most the maximum disk size of the server.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "volume": {
+          "description": "Name of a persistent volume.",
+          "type": "string"
+        }
+      }
+    },
+    "Event": {
+      "description": "VM lifecycle event",
+      "type": "object",
+      "properties": {
+        "message": {
+          "description": "Human readable details.",
+          "type": "string"
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        },
+        "time": {
+          "description": "Time the event was recorded.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "type": {
+          "description": "Event type.",
+          "type": "string",
+          "enum": [
+            "hibernated",
+            "restored"
+          ]
+        },
+        "vmid": {
+          "description": "ID of the VM.",
+          "type": "string"
         }
       }
     },
-    "/vm/run": {
-      "post": {
-        "description": "This endpoint creates a new VM and starts it",
-        "tags": [
-          "vm"
-        ],
-        "responses": {
-          "200": {
-            "description": "Success",
-            "schema": {
-              "$ref": "#/definitions/VM"
-            }
-          },
-          "500": {
-            "description": "Internal Server Error",
-            "schema": {
-              "$ref": "#/definitions/StandardError"
-            }
-          }
-        }
-      }
-    }
-  },
-  "definitions": {
     "HTTPRequest": {
       "type": "object",
       "properties": {
@@ -601,6 +2985,231 @@ func init() {
         }
       }
     },
+    "Lease": {
+      "description": "Guest IP leased on the built-in network",
+      "type": "object",
+      "properties": {
+        "bridge": {
+          "description": "Name of the bridge.",
+          "type": "string"
+        },
+        "created": {
+          "description": "Time the IP was leased.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "ip": {
+          "description": "Guest IP.",
+          "type": "string"
+        },
+        "vmid": {
+          "description": "ID of the VM owning the IP, the ID of the hibernated VM for a hibernated VM.",
+          "type": "string"
+        }
+      }
+    },
+    "NetworkAddress": {
+      "description": "IP address of a network interface",
+      "type": "object",
+      "properties": {
+        "gateway": {
+          "description": "Gateway of the subnet of the IP.",
+          "type": "string"
+        },
+        "ip": {
+          "description": "Guest IP with the prefix length.",
+          "type": "string"
+        }
+      }
+    },
+    "NetworkInterface": {
+      "description": "Network interface of a VM",
+      "type": "object",
+      "properties": {
+        "addresses": {
+          "description": "IPv4 and IPv6 addresses of the guest, the primary address first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NetworkAddress"
+          }
+        },
+        "allowMMDS": {
+          "description": "The MMDS is served on the interface.",
+          "type": "boolean"
+        },
+        "gateway": {
+          "description": "Gateway of the primary guest IP.",
+          "type": "string"
+        },
+        "hostDevName": {
+          "description": "Name of the tap device on the host.",
+          "type": "string"
+        },
+        "ifName": {
+          "description": "Name of the interface in the guest.",
+          "type": "string"
+        },
+        "ip": {
+          "description": "Primary guest IP with the prefix length, the first IPv4 address or the first IPv6 address\nof an IPv6 only guest.",
+          "type": "string"
+        },
+        "mac": {
+          "description": "MAC address of the guest.",
+          "type": "string"
+        },
+        "network": {
+          "description": "Name of the CNI network or of the bridge of the built-in network.",
+          "type": "string"
+        }
+      }
+    },
+    "NetworkInterfaceRequest": {
+      "description": "Network interface of a VM to be started",
+      "type": "object",
+      "required": [
+        "network"
+      ],
+      "properties": {
+        "allowMMDS": {
+          "description": "Serve the MMDS on the interface, requires MMDS.",
+          "type": "boolean"
+        },
+        "bandwidth": {
+          "$ref": "#/definitions/Bandwidth"
+        },
+        "ips": {
+          "description": "Guest IPs in CIDR notation requested from the IPAM plugin, requires CNI.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "mac": {
+          "description": "MAC address of the guest, requires CNI.",
+          "type": "string"
+        },
+        "network": {
+          "description": "Name of the CNI network when CNI is enabled, otherwise the name of the bridge.",
+          "type": "string"
+        },
+        "portMappings": {
+          "description": "Host ports forwarded to the guest by the portmap plugin, requires CNI.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PortMapping"
+          }
+        }
+      }
+    },
+    "PortMapping": {
+      "description": "Host port forwarded to a guest port",
+      "type": "object",
+      "required": [
+        "hostPort",
+        "guestPort"
+      ],
+      "properties": {
+        "guestPort": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 65535,
+          "minimum": 1
+        },
+        "hostIP": {
+          "description": "Host IP the mapping is restricted to.",
+          "type": "string"
+        },
+        "hostPort": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 65535,
+          "minimum": 1
+        },
+        "protocol": {
+          "description": "Protocol, tcp by default.",
+          "type": "string",
+          "enum": [
+            "tcp",
+            "udp",
+            "sctp"
+          ]
+        }
+      }
+    },
+    "Secret": {
+      "description": "Secret without its value",
+      "type": "object",
+      "properties": {
+        "name": {
+          "description": "Secret name.",
+          "type": "string"
+        },
+        "updated": {
+          "description": "Time the secret was last stored.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "version": {
+          "description": "Version of the secret, incremented on every rotation.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "SecretPutRequest": {
+      "description": "Secret value",
+      "type": "object",
+      "required": [
+        "value"
+      ],
+      "properties": {
+        "value": {
+          "description": "Secret value.",
+          "type": "string"
+        }
+      }
+    },
+    "Snapshot": {
+      "description": "Snapshot of a VM",
+      "type": "object",
+      "properties": {
+        "created": {
+          "description": "Time the snapshot was taken.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "ip": {
+          "description": "IP address of the VM the snapshot was taken from, the restored VMs keep it.",
+          "type": "string"
+        },
+        "name": {
+          "description": "Snapshot name.",
+          "type": "string"
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        },
+        "vmid": {
+          "description": "ID of the VM the snapshot was taken from.",
+          "type": "string"
+        }
+      }
+    },
+    "SnapshotCreateRequest": {
+      "description": "Snapshot specification",
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "description": "Snapshot name, letters, digits, '.', '_' and '-'.",
+          "type": "string",
+          "pattern": "^[A-Za-z0-9][A-Za-z0-9._-]*$"
+        }
+      }
+    },
     "StandardError": {
       "type": "object",
       "properties": {
@@ -624,13 +3233,139 @@ func init() {
       "description": "Virtual Machine",
       "type": "object",
       "properties": {
+        "conditions": {
+          "description": "Conditions of the VM, Ready and NetworkReady.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Condition"
+          }
+        },
         "id": {
           "description": "Virtual Machine ID.",
           "type": "string"
         },
+        "interfaces": {
+          "description": "Network interfaces of the VM in the guest order.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NetworkInterface"
+          }
+        },
         "ip": {
-          "description": "IP address of VM",
+          "description": "Primary IP address of the first network interface of the VM.",
+          "type": "string"
+        },
+        "ips": {
+          "description": "IPv4 and IPv6 addresses of the first network interface of the VM, the primary address first.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "labels": {
+          "description": "Labels of the VM.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        }
+      }
+    },
+    "VMMetrics": {
+      "description": "Firecracker metrics of the VM by group, the counters accumulated since the VM start and the last value of the gauges.",
+      "type": "object",
+      "additionalProperties": {
+        "type": "object",
+        "additionalProperties": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "VMRunRequest": {
+      "description": "Virtual Machine run specification",
+      "type": "object",
+      "properties": {
+        "drives": {
+          "description": "Additional block devices attached after the root drive, in order.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Drive"
+          }
+        },
+        "interfaces": {
+          "description": "Network interfaces of the VM in the guest order, replacing the interfaces of the service.\nA VM started from a snapshot has the interfaces of the snapshot.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NetworkInterfaceRequest"
+          }
+        },
+        "labels": {
+          "description": "Labels of the VM, added to the labels of the service.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "metadata": {
+          "description": "Metadata JSON the guest reads from the MMDS under the key \"metadata\", requires MMDS.",
+          "type": "object"
+        },
+        "profile": {
+          "description": "Name of the boot profile, defaults to the profile of the service.",
+          "type": "string"
+        },
+        "service": {
+          "description": "Name of the service the VM belongs to.",
+          "type": "string"
+        },
+        "snapshot": {
+          "description": "Name of the snapshot the VM is started from instead of booting the kernel.\nThe drives and the service are taken from the snapshot unless the service is given.",
+          "type": "string"
+        }
+      }
+    },
+    "Volume": {
+      "description": "Persistent volume",
+      "type": "object",
+      "properties": {
+        "attachedTo": {
+          "description": "ID of the VM the volume is attached to, empty when detached.",
+          "type": "string"
+        },
+        "name": {
+          "description": "Volume name.",
           "type": "string"
+        },
+        "sizeMib": {
+          "description": "Size of the volume.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "VolumeCreateRequest": {
+      "description": "Persistent volume specification",
+      "type": "object",
+      "required": [
+        "name",
+        "sizeMib"
+      ],
+      "properties": {
+        "name": {
+          "description": "Volume name, letters, digits, '.', '_' and '-'.",
+          "type": "string",
+          "pattern": "^[A-Za-z0-9][A-Za-z0-9._-]*$"
+        },
+        "sizeMib": {
+          "description": "Size of the volume, at most the maximum disk size of the server.",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
         }
       }
     }
@@ -0,0 +1,61 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package events
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// ListEventsOKCode is the HTTP code returned for type ListEventsOK
+const ListEventsOKCode int = 200
+
+/*ListEventsOK Success
+
+swagger:response listEventsOK
+*/
+type ListEventsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload []*models.Event `json:"body,omitempty"`
+}
+
+// NewListEventsOK creates ListEventsOK with default headers values
+func NewListEventsOK() *ListEventsOK {
+
+	return &ListEventsOK{}
+}
+
+// WithPayload adds the payload to the list events o k response
+func (o *ListEventsOK) WithPayload(payload []*models.Event) *ListEventsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list events o k response
+func (o *ListEventsOK) SetPayload(payload []*models.Event) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListEventsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = make([]*models.Event, 0, 50)
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
@@ -19,9 +19,15 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"github.com/combust-labs/firebox/api/server/restapi/events"
 	"github.com/combust-labs/firebox/api/server/restapi/health"
+	"github.com/combust-labs/firebox/api/server/restapi/network"
+	"github.com/combust-labs/firebox/api/server/restapi/profile"
+	"github.com/combust-labs/firebox/api/server/restapi/secret"
 	"github.com/combust-labs/firebox/api/server/restapi/service"
+	"github.com/combust-labs/firebox/api/server/restapi/snapshot"
 	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/api/server/restapi/volume"
 )
 
 // NewFireboxAPI creates a new Firebox instance
@@ -45,10 +51,41 @@ func NewFireboxAPI(spec *loads.Document) *FireboxAPI {
 		JSONConsumer: runtime.JSONConsumer(),
 
 		JSONProducer: runtime.JSONProducer(),
+		TxtProducer:  runtime.TextProducer(),
 
 		VMPostVMRunHandler: vm.PostVMRunHandlerFunc(func(params vm.PostVMRunParams) middleware.Responder {
 			return middleware.NotImplemented("operation vm.PostVMRun has not yet been implemented")
 		}),
+		VMAttachVMConsoleHandler: vm.AttachVMConsoleHandlerFunc(func(params vm.AttachVMConsoleParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.AttachVMConsole has not yet been implemented")
+		}),
+		SnapshotCreateSnapshotHandler: snapshot.CreateSnapshotHandlerFunc(func(params snapshot.CreateSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation snapshot.CreateSnapshot has not yet been implemented")
+		}),
+		VolumeCreateVolumeHandler: volume.CreateVolumeHandlerFunc(func(params volume.CreateVolumeParams) middleware.Responder {
+			return middleware.NotImplemented("operation volume.CreateVolume has not yet been implemented")
+		}),
+		SecretDeleteSecretHandler: secret.DeleteSecretHandlerFunc(func(params secret.DeleteSecretParams) middleware.Responder {
+			return middleware.NotImplemented("operation secret.DeleteSecret has not yet been implemented")
+		}),
+		SnapshotDeleteSnapshotHandler: snapshot.DeleteSnapshotHandlerFunc(func(params snapshot.DeleteSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation snapshot.DeleteSnapshot has not yet been implemented")
+		}),
+		VolumeDeleteVolumeHandler: volume.DeleteVolumeHandlerFunc(func(params volume.DeleteVolumeParams) middleware.Responder {
+			return middleware.NotImplemented("operation volume.DeleteVolume has not yet been implemented")
+		}),
+		VMGetVMHandler: vm.GetVMHandlerFunc(func(params vm.GetVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVM has not yet been implemented")
+		}),
+		VMGetVMBalloonHandler: vm.GetVMBalloonHandlerFunc(func(params vm.GetVMBalloonParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVMBalloon has not yet been implemented")
+		}),
+		VMGetVMLogsHandler: vm.GetVMLogsHandlerFunc(func(params vm.GetVMLogsParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVMLogs has not yet been implemented")
+		}),
+		VMGetVMMetricsHandler: vm.GetVMMetricsHandlerFunc(func(params vm.GetVMMetricsParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.GetVMMetrics has not yet been implemented")
+		}),
 		ServiceInvokeHandler: service.InvokeHandlerFunc(func(params service.InvokeParams) middleware.Responder {
 			return middleware.NotImplemented("operation service.Invoke has not yet been implemented")
 		}),
@@ -58,6 +95,39 @@ func NewFireboxAPI(spec *loads.Document) *FireboxAPI {
 		HealthIsReadyHandler: health.IsReadyHandlerFunc(func(params health.IsReadyParams) middleware.Responder {
 			return middleware.NotImplemented("operation health.IsReady has not yet been implemented")
 		}),
+		EventsListEventsHandler: events.ListEventsHandlerFunc(func(params events.ListEventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation events.ListEvents has not yet been implemented")
+		}),
+		NetworkListLeasesHandler: network.ListLeasesHandlerFunc(func(params network.ListLeasesParams) middleware.Responder {
+			return middleware.NotImplemented("operation network.ListLeases has not yet been implemented")
+		}),
+		ProfileListProfilesHandler: profile.ListProfilesHandlerFunc(func(params profile.ListProfilesParams) middleware.Responder {
+			return middleware.NotImplemented("operation profile.ListProfiles has not yet been implemented")
+		}),
+		SecretListSecretsHandler: secret.ListSecretsHandlerFunc(func(params secret.ListSecretsParams) middleware.Responder {
+			return middleware.NotImplemented("operation secret.ListSecrets has not yet been implemented")
+		}),
+		SnapshotListSnapshotsHandler: snapshot.ListSnapshotsHandlerFunc(func(params snapshot.ListSnapshotsParams) middleware.Responder {
+			return middleware.NotImplemented("operation snapshot.ListSnapshots has not yet been implemented")
+		}),
+		VolumeListVolumesHandler: volume.ListVolumesHandlerFunc(func(params volume.ListVolumesParams) middleware.Responder {
+			return middleware.NotImplemented("operation volume.ListVolumes has not yet been implemented")
+		}),
+		VMPauseVMHandler: vm.PauseVMHandlerFunc(func(params vm.PauseVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.PauseVM has not yet been implemented")
+		}),
+		SecretPutSecretHandler: secret.PutSecretHandlerFunc(func(params secret.PutSecretParams) middleware.Responder {
+			return middleware.NotImplemented("operation secret.PutSecret has not yet been implemented")
+		}),
+		VMPutVMMetadataHandler: vm.PutVMMetadataHandlerFunc(func(params vm.PutVMMetadataParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.PutVMMetadata has not yet been implemented")
+		}),
+		VMResumeVMHandler: vm.ResumeVMHandlerFunc(func(params vm.ResumeVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.ResumeVM has not yet been implemented")
+		}),
+		VMUpdateVMBalloonHandler: vm.UpdateVMBalloonHandlerFunc(func(params vm.UpdateVMBalloonParams) middleware.Responder {
+			return middleware.NotImplemented("operation vm.UpdateVMBalloon has not yet been implemented")
+		}),
 	}
 }
 
@@ -93,15 +163,60 @@ type FireboxAPI struct {
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
+	// TxtProducer registers a producer for the following mime types:
+	//   - text/plain
+	TxtProducer runtime.Producer
 
 	// VMPostVMRunHandler sets the operation handler for the post VM run operation
 	VMPostVMRunHandler vm.PostVMRunHandler
+	// VMAttachVMConsoleHandler sets the operation handler for the attach Vm console operation
+	VMAttachVMConsoleHandler vm.AttachVMConsoleHandler
+	// SnapshotCreateSnapshotHandler sets the operation handler for the create snapshot operation
+	SnapshotCreateSnapshotHandler snapshot.CreateSnapshotHandler
+	// VolumeCreateVolumeHandler sets the operation handler for the create volume operation
+	VolumeCreateVolumeHandler volume.CreateVolumeHandler
+	// SecretDeleteSecretHandler sets the operation handler for the delete secret operation
+	SecretDeleteSecretHandler secret.DeleteSecretHandler
+	// SnapshotDeleteSnapshotHandler sets the operation handler for the delete snapshot operation
+	SnapshotDeleteSnapshotHandler snapshot.DeleteSnapshotHandler
+	// VolumeDeleteVolumeHandler sets the operation handler for the delete volume operation
+	VolumeDeleteVolumeHandler volume.DeleteVolumeHandler
+	// VMGetVMHandler sets the operation handler for the get Vm operation
+	VMGetVMHandler vm.GetVMHandler
+	// VMGetVMBalloonHandler sets the operation handler for the get Vm balloon operation
+	VMGetVMBalloonHandler vm.GetVMBalloonHandler
+	// VMGetVMLogsHandler sets the operation handler for the get Vm logs operation
+	VMGetVMLogsHandler vm.GetVMLogsHandler
+	// VMGetVMMetricsHandler sets the operation handler for the get Vm metrics operation
+	VMGetVMMetricsHandler vm.GetVMMetricsHandler
 	// ServiceInvokeHandler sets the operation handler for the invoke operation
 	ServiceInvokeHandler service.InvokeHandler
 	// HealthIsHealthyHandler sets the operation handler for the is healthy operation
 	HealthIsHealthyHandler health.IsHealthyHandler
 	// HealthIsReadyHandler sets the operation handler for the is ready operation
 	HealthIsReadyHandler health.IsReadyHandler
+	// EventsListEventsHandler sets the operation handler for the list events operation
+	EventsListEventsHandler events.ListEventsHandler
+	// NetworkListLeasesHandler sets the operation handler for the list leases operation
+	NetworkListLeasesHandler network.ListLeasesHandler
+	// ProfileListProfilesHandler sets the operation handler for the list profiles operation
+	ProfileListProfilesHandler profile.ListProfilesHandler
+	// SecretListSecretsHandler sets the operation handler for the list secrets operation
+	SecretListSecretsHandler secret.ListSecretsHandler
+	// SnapshotListSnapshotsHandler sets the operation handler for the list snapshots operation
+	SnapshotListSnapshotsHandler snapshot.ListSnapshotsHandler
+	// VolumeListVolumesHandler sets the operation handler for the list volumes operation
+	VolumeListVolumesHandler volume.ListVolumesHandler
+	// VMPauseVMHandler sets the operation handler for the pause Vm operation
+	VMPauseVMHandler vm.PauseVMHandler
+	// SecretPutSecretHandler sets the operation handler for the put secret operation
+	SecretPutSecretHandler secret.PutSecretHandler
+	// VMPutVMMetadataHandler sets the operation handler for the put Vm metadata operation
+	VMPutVMMetadataHandler vm.PutVMMetadataHandler
+	// VMResumeVMHandler sets the operation handler for the resume Vm operation
+	VMResumeVMHandler vm.ResumeVMHandler
+	// VMUpdateVMBalloonHandler sets the operation handler for the update Vm balloon operation
+	VMUpdateVMBalloonHandler vm.UpdateVMBalloonHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -178,10 +293,43 @@ func (o *FireboxAPI) Validate() error {
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TxtProducer == nil {
+		unregistered = append(unregistered, "TxtProducer")
+	}
 
 	if o.VMPostVMRunHandler == nil {
 		unregistered = append(unregistered, "vm.PostVMRunHandler")
 	}
+	if o.VMAttachVMConsoleHandler == nil {
+		unregistered = append(unregistered, "vm.AttachVMConsoleHandler")
+	}
+	if o.SnapshotCreateSnapshotHandler == nil {
+		unregistered = append(unregistered, "snapshot.CreateSnapshotHandler")
+	}
+	if o.VolumeCreateVolumeHandler == nil {
+		unregistered = append(unregistered, "volume.CreateVolumeHandler")
+	}
+	if o.SecretDeleteSecretHandler == nil {
+		unregistered = append(unregistered, "secret.DeleteSecretHandler")
+	}
+	if o.SnapshotDeleteSnapshotHandler == nil {
+		unregistered = append(unregistered, "snapshot.DeleteSnapshotHandler")
+	}
+	if o.VolumeDeleteVolumeHandler == nil {
+		unregistered = append(unregistered, "volume.DeleteVolumeHandler")
+	}
+	if o.VMGetVMHandler == nil {
+		unregistered = append(unregistered, "vm.GetVMHandler")
+	}
+	if o.VMGetVMBalloonHandler == nil {
+		unregistered = append(unregistered, "vm.GetVMBalloonHandler")
+	}
+	if o.VMGetVMLogsHandler == nil {
+		unregistered = append(unregistered, "vm.GetVMLogsHandler")
+	}
+	if o.VMGetVMMetricsHandler == nil {
+		unregistered = append(unregistered, "vm.GetVMMetricsHandler")
+	}
 	if o.ServiceInvokeHandler == nil {
 		unregistered = append(unregistered, "service.InvokeHandler")
 	}
@@ -191,6 +339,39 @@ func (o *FireboxAPI) Validate() error {
 	if o.HealthIsReadyHandler == nil {
 		unregistered = append(unregistered, "health.IsReadyHandler")
 	}
+	if o.EventsListEventsHandler == nil {
+		unregistered = append(unregistered, "events.ListEventsHandler")
+	}
+	if o.NetworkListLeasesHandler == nil {
+		unregistered = append(unregistered, "network.ListLeasesHandler")
+	}
+	if o.ProfileListProfilesHandler == nil {
+		unregistered = append(unregistered, "profile.ListProfilesHandler")
+	}
+	if o.SecretListSecretsHandler == nil {
+		unregistered = append(unregistered, "secret.ListSecretsHandler")
+	}
+	if o.SnapshotListSnapshotsHandler == nil {
+		unregistered = append(unregistered, "snapshot.ListSnapshotsHandler")
+	}
+	if o.VolumeListVolumesHandler == nil {
+		unregistered = append(unregistered, "volume.ListVolumesHandler")
+	}
+	if o.VMPauseVMHandler == nil {
+		unregistered = append(unregistered, "vm.PauseVMHandler")
+	}
+	if o.SecretPutSecretHandler == nil {
+		unregistered = append(unregistered, "secret.PutSecretHandler")
+	}
+	if o.VMPutVMMetadataHandler == nil {
+		unregistered = append(unregistered, "vm.PutVMMetadataHandler")
+	}
+	if o.VMResumeVMHandler == nil {
+		unregistered = append(unregistered, "vm.ResumeVMHandler")
+	}
+	if o.VMUpdateVMBalloonHandler == nil {
+		unregistered = append(unregistered, "vm.UpdateVMBalloonHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -239,6 +420,8 @@ func (o *FireboxAPI) ProducersFor(mediaTypes []string) map[string]runtime.Produc
 		switch mt {
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "text/plain":
+			result["text/plain"] = o.TxtProducer
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -283,6 +466,46 @@ func (o *FireboxAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/vm/run"] = vm.NewPostVMRun(o.context, o.VMPostVMRunHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vm/{id}/console"] = vm.NewAttachVMConsole(o.context, o.VMAttachVMConsoleHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/vm/{id}/snapshot"] = snapshot.NewCreateSnapshot(o.context, o.SnapshotCreateSnapshotHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/volumes"] = volume.NewCreateVolume(o.context, o.VolumeCreateVolumeHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/secrets/{name}"] = secret.NewDeleteSecret(o.context, o.SecretDeleteSecretHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/snapshots/{name}"] = snapshot.NewDeleteSnapshot(o.context, o.SnapshotDeleteSnapshotHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/volumes/{name}"] = volume.NewDeleteVolume(o.context, o.VolumeDeleteVolumeHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vm/{id}"] = vm.NewGetVM(o.context, o.VMGetVMHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vm/{id}/balloon"] = vm.NewGetVMBalloon(o.context, o.VMGetVMBalloonHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vm/{id}/logs"] = vm.NewGetVMLogs(o.context, o.VMGetVMLogsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vm/{id}/metrics"] = vm.NewGetVMMetrics(o.context, o.VMGetVMMetricsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -295,6 +518,50 @@ func (o *FireboxAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/-/ready"] = health.NewIsReady(o.context, o.HealthIsReadyHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/events"] = events.NewListEvents(o.context, o.EventsListEventsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/network/leases"] = network.NewListLeases(o.context, o.NetworkListLeasesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/profiles"] = profile.NewListProfiles(o.context, o.ProfileListProfilesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/secrets"] = secret.NewListSecrets(o.context, o.SecretListSecretsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/snapshots"] = snapshot.NewListSnapshots(o.context, o.SnapshotListSnapshotsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/volumes"] = volume.NewListVolumes(o.context, o.VolumeListVolumesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/vm/{id}/pause"] = vm.NewPauseVM(o.context, o.VMPauseVMHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/secrets/{name}"] = secret.NewPutSecret(o.context, o.SecretPutSecretHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/vm/{id}/metadata"] = vm.NewPutVMMetadata(o.context, o.VMPutVMMetadataHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/vm/{id}/resume"] = vm.NewResumeVM(o.context, o.VMResumeVMHandler)
+	if o.handlers["PATCH"] == nil {
+		o.handlers["PATCH"] = make(map[string]http.Handler)
+	}
+	o.handlers["PATCH"]["/vm/{id}/balloon"] = vm.NewUpdateVMBalloon(o.context, o.VMUpdateVMBalloonHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package network
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListLeasesHandlerFunc turns a function with the right signature into a list leases handler
+type ListLeasesHandlerFunc func(ListLeasesParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListLeasesHandlerFunc) Handle(params ListLeasesParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListLeasesHandler interface for that can handle valid list leases params
+type ListLeasesHandler interface {
+	Handle(ListLeasesParams) middleware.Responder
+}
+
+// NewListLeases creates a new http.Handler for the list leases operation
+func NewListLeases(ctx *middleware.Context, handler ListLeasesHandler) *ListLeases {
+	return &ListLeases{Context: ctx, Handler: handler}
+}
+
+/* ListLeases swagger:route GET /network/leases network listLeases
+
+This endpoint lists the guest IPs leased on the bridges of the built-in network, the list is empty when CNI is enabled.
+
+*/
+type ListLeases struct {
+	Context *middleware.Context
+	Handler ListLeasesHandler
+}
+
+func (o *ListLeases) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewListLeasesParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,105 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package network
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// ListLeasesOKCode is the HTTP code returned for type ListLeasesOK
+const ListLeasesOKCode int = 200
+
+/*ListLeasesOK Success
+
+swagger:response listLeasesOK
+*/
+type ListLeasesOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload []*models.Lease `json:"body,omitempty"`
+}
+
+// NewListLeasesOK creates ListLeasesOK with default headers values
+func NewListLeasesOK() *ListLeasesOK {
+
+	return &ListLeasesOK{}
+}
+
+// WithPayload adds the payload to the list leases o k response
+func (o *ListLeasesOK) WithPayload(payload []*models.Lease) *ListLeasesOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list leases o k response
+func (o *ListLeasesOK) SetPayload(payload []*models.Lease) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListLeasesOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = make([]*models.Lease, 0, 50)
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// ListLeasesInternalServerErrorCode is the HTTP code returned for type ListLeasesInternalServerError
+const ListLeasesInternalServerErrorCode int = 500
+
+/*ListLeasesInternalServerError Internal Server Error
+
+swagger:response listLeasesInternalServerError
+*/
+type ListLeasesInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewListLeasesInternalServerError creates ListLeasesInternalServerError with default headers values
+func NewListLeasesInternalServerError() *ListLeasesInternalServerError {
+
+	return &ListLeasesInternalServerError{}
+}
+
+// WithPayload adds the payload to the list leases internal server error response
+func (o *ListLeasesInternalServerError) WithPayload(payload *models.StandardError) *ListLeasesInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list leases internal server error response
+func (o *ListLeasesInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListLeasesInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package profile
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListProfilesHandlerFunc turns a function with the right signature into a list profiles handler
+type ListProfilesHandlerFunc func(ListProfilesParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListProfilesHandlerFunc) Handle(params ListProfilesParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListProfilesHandler interface for that can handle valid list profiles params
+type ListProfilesHandler interface {
+	Handle(ListProfilesParams) middleware.Responder
+}
+
+// NewListProfiles creates a new http.Handler for the list profiles operation
+func NewListProfiles(ctx *middleware.Context, handler ListProfilesHandler) *ListProfiles {
+	return &ListProfiles{Context: ctx, Handler: handler}
+}
+
+/* ListProfiles swagger:route GET /profiles profile listProfiles
+
+This endpoint lists the boot profiles.
+
+*/
+type ListProfiles struct {
+	Context *middleware.Context
+	Handler ListProfilesHandler
+}
+
+func (o *ListProfiles) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewListProfilesParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,61 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package profile
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// ListProfilesOKCode is the HTTP code returned for type ListProfilesOK
+const ListProfilesOKCode int = 200
+
+/*ListProfilesOK Success
+
+swagger:response listProfilesOK
+*/
+type ListProfilesOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload []*models.BootProfile `json:"body,omitempty"`
+}
+
+// NewListProfilesOK creates ListProfilesOK with default headers values
+func NewListProfilesOK() *ListProfilesOK {
+
+	return &ListProfilesOK{}
+}
+
+// WithPayload adds the payload to the list profiles o k response
+func (o *ListProfilesOK) WithPayload(payload []*models.BootProfile) *ListProfilesOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list profiles o k response
+func (o *ListProfilesOK) SetPayload(payload []*models.BootProfile) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListProfilesOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = make([]*models.BootProfile, 0, 50)
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secret
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DeleteSecretHandlerFunc turns a function with the right signature into a delete secret handler
+type DeleteSecretHandlerFunc func(DeleteSecretParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DeleteSecretHandlerFunc) Handle(params DeleteSecretParams) middleware.Responder {
+	return fn(params)
+}
+
+// DeleteSecretHandler interface for that can handle valid delete secret params
+type DeleteSecretHandler interface {
+	Handle(DeleteSecretParams) middleware.Responder
+}
+
+// NewDeleteSecret creates a new http.Handler for the delete secret operation
+func NewDeleteSecret(ctx *middleware.Context, handler DeleteSecretHandler) *DeleteSecret {
+	return &DeleteSecret{Context: ctx, Handler: handler}
+}
+
+/* DeleteSecret swagger:route DELETE /secrets/{name} secret deleteSecret
+
+This endpoint deletes a secret, the running VMs keep its value.
+
+*/
+type DeleteSecret struct {
+	Context *middleware.Context
+	Handler DeleteSecretHandler
+}
+
+func (o *DeleteSecret) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewDeleteSecretParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,126 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secret
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// DeleteSecretNoContentCode is the HTTP code returned for type DeleteSecretNoContent
+const DeleteSecretNoContentCode int = 204
+
+/*DeleteSecretNoContent Deleted
+
+swagger:response deleteSecretNoContent
+*/
+type DeleteSecretNoContent struct {
+}
+
+// NewDeleteSecretNoContent creates DeleteSecretNoContent with default headers values
+func NewDeleteSecretNoContent() *DeleteSecretNoContent {
+
+	return &DeleteSecretNoContent{}
+}
+
+// WriteResponse to the client
+func (o *DeleteSecretNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(204)
+}
+
+// DeleteSecretNotFoundCode is the HTTP code returned for type DeleteSecretNotFound
+const DeleteSecretNotFoundCode int = 404
+
+/*DeleteSecretNotFound Not Found
+
+swagger:response deleteSecretNotFound
+*/
+type DeleteSecretNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewDeleteSecretNotFound creates DeleteSecretNotFound with default headers values
+func NewDeleteSecretNotFound() *DeleteSecretNotFound {
+
+	return &DeleteSecretNotFound{}
+}
+
+// WithPayload adds the payload to the delete secret not found response
+func (o *DeleteSecretNotFound) WithPayload(payload *models.StandardError) *DeleteSecretNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete secret not found response
+func (o *DeleteSecretNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteSecretNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DeleteSecretInternalServerErrorCode is the HTTP code returned for type DeleteSecretInternalServerError
+const DeleteSecretInternalServerErrorCode int = 500
+
+/*DeleteSecretInternalServerError Internal Server Error
+
+swagger:response deleteSecretInternalServerError
+*/
+type DeleteSecretInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewDeleteSecretInternalServerError creates DeleteSecretInternalServerError with default headers values
+func NewDeleteSecretInternalServerError() *DeleteSecretInternalServerError {
+
+	return &DeleteSecretInternalServerError{}
+}
+
+// WithPayload adds the payload to the delete secret internal server error response
+func (o *DeleteSecretInternalServerError) WithPayload(payload *models.StandardError) *DeleteSecretInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete secret internal server error response
+func (o *DeleteSecretInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteSecretInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secret
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListSecretsHandlerFunc turns a function with the right signature into a list secrets handler
+type ListSecretsHandlerFunc func(ListSecretsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListSecretsHandlerFunc) Handle(params ListSecretsParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListSecretsHandler interface for that can handle valid list secrets params
+type ListSecretsHandler interface {
+	Handle(ListSecretsParams) middleware.Responder
+}
+
+// NewListSecrets creates a new http.Handler for the list secrets operation
+func NewListSecrets(ctx *middleware.Context, handler ListSecretsHandler) *ListSecrets {
+	return &ListSecrets{Context: ctx, Handler: handler}
+}
+
+/* ListSecrets swagger:route GET /secrets secret listSecrets
+
+This endpoint lists the secrets, the values are never returned.
+
+*/
+type ListSecrets struct {
+	Context *middleware.Context
+	Handler ListSecretsHandler
+}
+
+func (o *ListSecrets) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewListSecretsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
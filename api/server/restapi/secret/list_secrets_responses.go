@@ -0,0 +1,105 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secret
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// ListSecretsOKCode is the HTTP code returned for type ListSecretsOK
+const ListSecretsOKCode int = 200
+
+/*ListSecretsOK Success
+
+swagger:response listSecretsOK
+*/
+type ListSecretsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload []*models.Secret `json:"body,omitempty"`
+}
+
+// NewListSecretsOK creates ListSecretsOK with default headers values
+func NewListSecretsOK() *ListSecretsOK {
+
+	return &ListSecretsOK{}
+}
+
+// WithPayload adds the payload to the list secrets o k response
+func (o *ListSecretsOK) WithPayload(payload []*models.Secret) *ListSecretsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list secrets o k response
+func (o *ListSecretsOK) SetPayload(payload []*models.Secret) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListSecretsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = make([]*models.Secret, 0, 50)
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// ListSecretsInternalServerErrorCode is the HTTP code returned for type ListSecretsInternalServerError
+const ListSecretsInternalServerErrorCode int = 500
+
+/*ListSecretsInternalServerError Internal Server Error
+
+swagger:response listSecretsInternalServerError
+*/
+type ListSecretsInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewListSecretsInternalServerError creates ListSecretsInternalServerError with default headers values
+func NewListSecretsInternalServerError() *ListSecretsInternalServerError {
+
+	return &ListSecretsInternalServerError{}
+}
+
+// WithPayload adds the payload to the list secrets internal server error response
+func (o *ListSecretsInternalServerError) WithPayload(payload *models.StandardError) *ListSecretsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list secrets internal server error response
+func (o *ListSecretsInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListSecretsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secret
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PutSecretHandlerFunc turns a function with the right signature into a put secret handler
+type PutSecretHandlerFunc func(PutSecretParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PutSecretHandlerFunc) Handle(params PutSecretParams) middleware.Responder {
+	return fn(params)
+}
+
+// PutSecretHandler interface for that can handle valid put secret params
+type PutSecretHandler interface {
+	Handle(PutSecretParams) middleware.Responder
+}
+
+// NewPutSecret creates a new http.Handler for the put secret operation
+func NewPutSecret(ctx *middleware.Context, handler PutSecretHandler) *PutSecret {
+	return &PutSecret{Context: ctx, Handler: handler}
+}
+
+/* PutSecret swagger:route PUT /secrets/{name} secret putSecret
+
+This endpoint creates or rotates a secret. The running VMs receiving their environment through the MMDS get the new value.
+
+*/
+type PutSecret struct {
+	Context *middleware.Context
+	Handler PutSecretHandler
+}
+
+func (o *PutSecret) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewPutSecretParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
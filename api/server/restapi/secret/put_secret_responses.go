@@ -0,0 +1,146 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secret
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// PutSecretOKCode is the HTTP code returned for type PutSecretOK
+const PutSecretOKCode int = 200
+
+/*PutSecretOK Stored
+
+swagger:response putSecretOK
+*/
+type PutSecretOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Secret `json:"body,omitempty"`
+}
+
+// NewPutSecretOK creates PutSecretOK with default headers values
+func NewPutSecretOK() *PutSecretOK {
+
+	return &PutSecretOK{}
+}
+
+// WithPayload adds the payload to the put secret o k response
+func (o *PutSecretOK) WithPayload(payload *models.Secret) *PutSecretOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put secret o k response
+func (o *PutSecretOK) SetPayload(payload *models.Secret) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutSecretOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PutSecretBadRequestCode is the HTTP code returned for type PutSecretBadRequest
+const PutSecretBadRequestCode int = 400
+
+/*PutSecretBadRequest Bad Request
+
+swagger:response putSecretBadRequest
+*/
+type PutSecretBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPutSecretBadRequest creates PutSecretBadRequest with default headers values
+func NewPutSecretBadRequest() *PutSecretBadRequest {
+
+	return &PutSecretBadRequest{}
+}
+
+// WithPayload adds the payload to the put secret bad request response
+func (o *PutSecretBadRequest) WithPayload(payload *models.StandardError) *PutSecretBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put secret bad request response
+func (o *PutSecretBadRequest) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutSecretBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PutSecretInternalServerErrorCode is the HTTP code returned for type PutSecretInternalServerError
+const PutSecretInternalServerErrorCode int = 500
+
+/*PutSecretInternalServerError Internal Server Error
+
+swagger:response putSecretInternalServerError
+*/
+type PutSecretInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPutSecretInternalServerError creates PutSecretInternalServerError with default headers values
+func NewPutSecretInternalServerError() *PutSecretInternalServerError {
+
+	return &PutSecretInternalServerError{}
+}
+
+// WithPayload adds the payload to the put secret internal server error response
+func (o *PutSecretInternalServerError) WithPayload(payload *models.StandardError) *PutSecretInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put secret internal server error response
+func (o *PutSecretInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutSecretInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -13,17 +13,25 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/validate"
 
 	"github.com/combust-labs/firebox/api/models"
 )
 
 // NewInvokeParams creates a new InvokeParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewInvokeParams() InvokeParams {
 
-	return InvokeParams{}
+	var (
+		// initialize parameters with default values
+
+		serviceDefault = string("default")
+	)
+
+	return InvokeParams{
+		Service: &serviceDefault,
+	}
 }
 
 // InvokeParams contains all the bound params for the invoke operation
@@ -40,6 +48,11 @@ type InvokeParams struct {
 	  In: body
 	*/
 	Data *models.HTTPRequest
+	/*Name of the service to invoke.
+	  In: query
+	  Default: "default"
+	*/
+	Service *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -51,6 +64,8 @@ func (o *InvokeParams) BindRequest(r *http.Request, route *middleware.MatchedRou
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.HTTPRequest
@@ -78,8 +93,32 @@ func (o *InvokeParams) BindRequest(r *http.Request, route *middleware.MatchedRou
 	} else {
 		res = append(res, errors.Required("data", "body", ""))
 	}
+
+	qService, qhkService, _ := qs.GetOK("service")
+	if err := o.bindService(qService, qhkService, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindService binds and validates parameter Service from query.
+func (o *InvokeParams) bindService(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewInvokeParams()
+		return nil
+	}
+	o.Service = &raw
+
+	return nil
+}
@@ -13,7 +13,11 @@ import (
 
 // InvokeURL generates an URL for the invoke operation
 type InvokeURL struct {
+	Service *string
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -43,6 +47,18 @@ func (o *InvokeURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var serviceQ string
+	if o.Service != nil {
+		serviceQ = *o.Service
+	}
+	if serviceQ != "" {
+		qs.Set("service", serviceQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -0,0 +1,234 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package snapshot
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// CreateSnapshotCreatedCode is the HTTP code returned for type CreateSnapshotCreated
+const CreateSnapshotCreatedCode int = 201
+
+/*CreateSnapshotCreated Created
+
+swagger:response createSnapshotCreated
+*/
+type CreateSnapshotCreated struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Snapshot `json:"body,omitempty"`
+}
+
+// NewCreateSnapshotCreated creates CreateSnapshotCreated with default headers values
+func NewCreateSnapshotCreated() *CreateSnapshotCreated {
+
+	return &CreateSnapshotCreated{}
+}
+
+// WithPayload adds the payload to the create snapshot created response
+func (o *CreateSnapshotCreated) WithPayload(payload *models.Snapshot) *CreateSnapshotCreated {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create snapshot created response
+func (o *CreateSnapshotCreated) SetPayload(payload *models.Snapshot) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateSnapshotCreated) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(201)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CreateSnapshotBadRequestCode is the HTTP code returned for type CreateSnapshotBadRequest
+const CreateSnapshotBadRequestCode int = 400
+
+/*CreateSnapshotBadRequest Bad Request
+
+swagger:response createSnapshotBadRequest
+*/
+type CreateSnapshotBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewCreateSnapshotBadRequest creates CreateSnapshotBadRequest with default headers values
+func NewCreateSnapshotBadRequest() *CreateSnapshotBadRequest {
+
+	return &CreateSnapshotBadRequest{}
+}
+
+// WithPayload adds the payload to the create snapshot bad request response
+func (o *CreateSnapshotBadRequest) WithPayload(payload *models.StandardError) *CreateSnapshotBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create snapshot bad request response
+func (o *CreateSnapshotBadRequest) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateSnapshotBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CreateSnapshotNotFoundCode is the HTTP code returned for type CreateSnapshotNotFound
+const CreateSnapshotNotFoundCode int = 404
+
+/*CreateSnapshotNotFound Not Found
+
+swagger:response createSnapshotNotFound
+*/
+type CreateSnapshotNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewCreateSnapshotNotFound creates CreateSnapshotNotFound with default headers values
+func NewCreateSnapshotNotFound() *CreateSnapshotNotFound {
+
+	return &CreateSnapshotNotFound{}
+}
+
+// WithPayload adds the payload to the create snapshot not found response
+func (o *CreateSnapshotNotFound) WithPayload(payload *models.StandardError) *CreateSnapshotNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create snapshot not found response
+func (o *CreateSnapshotNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateSnapshotNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CreateSnapshotConflictCode is the HTTP code returned for type CreateSnapshotConflict
+const CreateSnapshotConflictCode int = 409
+
+/*CreateSnapshotConflict Conflict
+
+swagger:response createSnapshotConflict
+*/
+type CreateSnapshotConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewCreateSnapshotConflict creates CreateSnapshotConflict with default headers values
+func NewCreateSnapshotConflict() *CreateSnapshotConflict {
+
+	return &CreateSnapshotConflict{}
+}
+
+// WithPayload adds the payload to the create snapshot conflict response
+func (o *CreateSnapshotConflict) WithPayload(payload *models.StandardError) *CreateSnapshotConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create snapshot conflict response
+func (o *CreateSnapshotConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateSnapshotConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CreateSnapshotInternalServerErrorCode is the HTTP code returned for type CreateSnapshotInternalServerError
+const CreateSnapshotInternalServerErrorCode int = 500
+
+/*CreateSnapshotInternalServerError Internal Server Error
+
+swagger:response createSnapshotInternalServerError
+*/
+type CreateSnapshotInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewCreateSnapshotInternalServerError creates CreateSnapshotInternalServerError with default headers values
+func NewCreateSnapshotInternalServerError() *CreateSnapshotInternalServerError {
+
+	return &CreateSnapshotInternalServerError{}
+}
+
+// WithPayload adds the payload to the create snapshot internal server error response
+func (o *CreateSnapshotInternalServerError) WithPayload(payload *models.StandardError) *CreateSnapshotInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create snapshot internal server error response
+func (o *CreateSnapshotInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateSnapshotInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
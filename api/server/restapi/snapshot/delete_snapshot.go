@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package snapshot
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DeleteSnapshotHandlerFunc turns a function with the right signature into a delete snapshot handler
+type DeleteSnapshotHandlerFunc func(DeleteSnapshotParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DeleteSnapshotHandlerFunc) Handle(params DeleteSnapshotParams) middleware.Responder {
+	return fn(params)
+}
+
+// DeleteSnapshotHandler interface for that can handle valid delete snapshot params
+type DeleteSnapshotHandler interface {
+	Handle(DeleteSnapshotParams) middleware.Responder
+}
+
+// NewDeleteSnapshot creates a new http.Handler for the delete snapshot operation
+func NewDeleteSnapshot(ctx *middleware.Context, handler DeleteSnapshotHandler) *DeleteSnapshot {
+	return &DeleteSnapshot{Context: ctx, Handler: handler}
+}
+
+/* DeleteSnapshot swagger:route DELETE /snapshots/{name} snapshot deleteSnapshot
+
+This endpoint deletes a snapshot, the VMs started from it keep running.
+
+*/
+type DeleteSnapshot struct {
+	Context *middleware.Context
+	Handler DeleteSnapshotHandler
+}
+
+func (o *DeleteSnapshot) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewDeleteSnapshotParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,126 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package snapshot
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// DeleteSnapshotNoContentCode is the HTTP code returned for type DeleteSnapshotNoContent
+const DeleteSnapshotNoContentCode int = 204
+
+/*DeleteSnapshotNoContent Deleted
+
+swagger:response deleteSnapshotNoContent
+*/
+type DeleteSnapshotNoContent struct {
+}
+
+// NewDeleteSnapshotNoContent creates DeleteSnapshotNoContent with default headers values
+func NewDeleteSnapshotNoContent() *DeleteSnapshotNoContent {
+
+	return &DeleteSnapshotNoContent{}
+}
+
+// WriteResponse to the client
+func (o *DeleteSnapshotNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(204)
+}
+
+// DeleteSnapshotNotFoundCode is the HTTP code returned for type DeleteSnapshotNotFound
+const DeleteSnapshotNotFoundCode int = 404
+
+/*DeleteSnapshotNotFound Not Found
+
+swagger:response deleteSnapshotNotFound
+*/
+type DeleteSnapshotNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewDeleteSnapshotNotFound creates DeleteSnapshotNotFound with default headers values
+func NewDeleteSnapshotNotFound() *DeleteSnapshotNotFound {
+
+	return &DeleteSnapshotNotFound{}
+}
+
+// WithPayload adds the payload to the delete snapshot not found response
+func (o *DeleteSnapshotNotFound) WithPayload(payload *models.StandardError) *DeleteSnapshotNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete snapshot not found response
+func (o *DeleteSnapshotNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteSnapshotNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DeleteSnapshotInternalServerErrorCode is the HTTP code returned for type DeleteSnapshotInternalServerError
+const DeleteSnapshotInternalServerErrorCode int = 500
+
+/*DeleteSnapshotInternalServerError Internal Server Error
+
+swagger:response deleteSnapshotInternalServerError
+*/
+type DeleteSnapshotInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewDeleteSnapshotInternalServerError creates DeleteSnapshotInternalServerError with default headers values
+func NewDeleteSnapshotInternalServerError() *DeleteSnapshotInternalServerError {
+
+	return &DeleteSnapshotInternalServerError{}
+}
+
+// WithPayload adds the payload to the delete snapshot internal server error response
+func (o *DeleteSnapshotInternalServerError) WithPayload(payload *models.StandardError) *DeleteSnapshotInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete snapshot internal server error response
+func (o *DeleteSnapshotInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteSnapshotInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package snapshot
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListSnapshotsHandlerFunc turns a function with the right signature into a list snapshots handler
+type ListSnapshotsHandlerFunc func(ListSnapshotsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListSnapshotsHandlerFunc) Handle(params ListSnapshotsParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListSnapshotsHandler interface for that can handle valid list snapshots params
+type ListSnapshotsHandler interface {
+	Handle(ListSnapshotsParams) middleware.Responder
+}
+
+// NewListSnapshots creates a new http.Handler for the list snapshots operation
+func NewListSnapshots(ctx *middleware.Context, handler ListSnapshotsHandler) *ListSnapshots {
+	return &ListSnapshots{Context: ctx, Handler: handler}
+}
+
+/* ListSnapshots swagger:route GET /snapshots snapshot listSnapshots
+
+This endpoint lists the snapshots.
+
+*/
+type ListSnapshots struct {
+	Context *middleware.Context
+	Handler ListSnapshotsHandler
+}
+
+func (o *ListSnapshots) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewListSnapshotsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,105 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package snapshot
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// ListSnapshotsOKCode is the HTTP code returned for type ListSnapshotsOK
+const ListSnapshotsOKCode int = 200
+
+/*ListSnapshotsOK Success
+
+swagger:response listSnapshotsOK
+*/
+type ListSnapshotsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload []*models.Snapshot `json:"body,omitempty"`
+}
+
+// NewListSnapshotsOK creates ListSnapshotsOK with default headers values
+func NewListSnapshotsOK() *ListSnapshotsOK {
+
+	return &ListSnapshotsOK{}
+}
+
+// WithPayload adds the payload to the list snapshots o k response
+func (o *ListSnapshotsOK) WithPayload(payload []*models.Snapshot) *ListSnapshotsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list snapshots o k response
+func (o *ListSnapshotsOK) SetPayload(payload []*models.Snapshot) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListSnapshotsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = make([]*models.Snapshot, 0, 50)
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// ListSnapshotsInternalServerErrorCode is the HTTP code returned for type ListSnapshotsInternalServerError
+const ListSnapshotsInternalServerErrorCode int = 500
+
+/*ListSnapshotsInternalServerError Internal Server Error
+
+swagger:response listSnapshotsInternalServerError
+*/
+type ListSnapshotsInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewListSnapshotsInternalServerError creates ListSnapshotsInternalServerError with default headers values
+func NewListSnapshotsInternalServerError() *ListSnapshotsInternalServerError {
+
+	return &ListSnapshotsInternalServerError{}
+}
+
+// WithPayload adds the payload to the list snapshots internal server error response
+func (o *ListSnapshotsInternalServerError) WithPayload(payload *models.StandardError) *ListSnapshotsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list snapshots internal server error response
+func (o *ListSnapshotsInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListSnapshotsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// AttachVMConsoleHandlerFunc turns a function with the right signature into a attach Vm console handler
+type AttachVMConsoleHandlerFunc func(AttachVMConsoleParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn AttachVMConsoleHandlerFunc) Handle(params AttachVMConsoleParams) middleware.Responder {
+	return fn(params)
+}
+
+// AttachVMConsoleHandler interface for that can handle valid attach Vm console params
+type AttachVMConsoleHandler interface {
+	Handle(AttachVMConsoleParams) middleware.Responder
+}
+
+// NewAttachVMConsole creates a new http.Handler for the attach Vm console operation
+func NewAttachVMConsole(ctx *middleware.Context, handler AttachVMConsoleHandler) *AttachVMConsole {
+	return &AttachVMConsole{Context: ctx, Handler: handler}
+}
+
+/* AttachVMConsole swagger:route GET /vm/{id}/console vm attachVmConsole
+
+This endpoint upgrades the connection to a WebSocket attached to the serial console of the VM.
+Binary or text messages sent by the client are written to the console input,
+the console output is sent to the client as binary messages.
+
+*/
+type AttachVMConsole struct {
+	Context *middleware.Context
+	Handler AttachVMConsoleHandler
+}
+
+func (o *AttachVMConsole) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewAttachVMConsoleParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,126 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// AttachVMConsoleSwitchingProtocolsCode is the HTTP code returned for type AttachVMConsoleSwitchingProtocols
+const AttachVMConsoleSwitchingProtocolsCode int = 101
+
+/*AttachVMConsoleSwitchingProtocols Switching Protocols
+
+swagger:response attachVmConsoleSwitchingProtocols
+*/
+type AttachVMConsoleSwitchingProtocols struct {
+}
+
+// NewAttachVMConsoleSwitchingProtocols creates AttachVMConsoleSwitchingProtocols with default headers values
+func NewAttachVMConsoleSwitchingProtocols() *AttachVMConsoleSwitchingProtocols {
+
+	return &AttachVMConsoleSwitchingProtocols{}
+}
+
+// WriteResponse to the client
+func (o *AttachVMConsoleSwitchingProtocols) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(101)
+}
+
+// AttachVMConsoleNotFoundCode is the HTTP code returned for type AttachVMConsoleNotFound
+const AttachVMConsoleNotFoundCode int = 404
+
+/*AttachVMConsoleNotFound Not Found
+
+swagger:response attachVmConsoleNotFound
+*/
+type AttachVMConsoleNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewAttachVMConsoleNotFound creates AttachVMConsoleNotFound with default headers values
+func NewAttachVMConsoleNotFound() *AttachVMConsoleNotFound {
+
+	return &AttachVMConsoleNotFound{}
+}
+
+// WithPayload adds the payload to the attach Vm console not found response
+func (o *AttachVMConsoleNotFound) WithPayload(payload *models.StandardError) *AttachVMConsoleNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the attach Vm console not found response
+func (o *AttachVMConsoleNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *AttachVMConsoleNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// AttachVMConsoleInternalServerErrorCode is the HTTP code returned for type AttachVMConsoleInternalServerError
+const AttachVMConsoleInternalServerErrorCode int = 500
+
+/*AttachVMConsoleInternalServerError Internal Server Error
+
+swagger:response attachVmConsoleInternalServerError
+*/
+type AttachVMConsoleInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewAttachVMConsoleInternalServerError creates AttachVMConsoleInternalServerError with default headers values
+func NewAttachVMConsoleInternalServerError() *AttachVMConsoleInternalServerError {
+
+	return &AttachVMConsoleInternalServerError{}
+}
+
+// WithPayload adds the payload to the attach Vm console internal server error response
+func (o *AttachVMConsoleInternalServerError) WithPayload(payload *models.StandardError) *AttachVMConsoleInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the attach Vm console internal server error response
+func (o *AttachVMConsoleInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *AttachVMConsoleInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
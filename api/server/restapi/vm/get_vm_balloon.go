@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetVMBalloonHandlerFunc turns a function with the right signature into a get Vm balloon handler
+type GetVMBalloonHandlerFunc func(GetVMBalloonParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetVMBalloonHandlerFunc) Handle(params GetVMBalloonParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetVMBalloonHandler interface for that can handle valid get Vm balloon params
+type GetVMBalloonHandler interface {
+	Handle(GetVMBalloonParams) middleware.Responder
+}
+
+// NewGetVMBalloon creates a new http.Handler for the get Vm balloon operation
+func NewGetVMBalloon(ctx *middleware.Context, handler GetVMBalloonHandler) *GetVMBalloon {
+	return &GetVMBalloon{Context: ctx, Handler: handler}
+}
+
+/* GetVMBalloon swagger:route GET /vm/{id}/balloon vm getVmBalloon
+
+This endpoint returns the balloon size and the guest memory statistics of the VM.
+
+*/
+type GetVMBalloon struct {
+	Context *middleware.Context
+	Handler GetVMBalloonHandler
+}
+
+func (o *GetVMBalloon) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewGetVMBalloonParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,190 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// GetVMBalloonOKCode is the HTTP code returned for type GetVMBalloonOK
+const GetVMBalloonOKCode int = 200
+
+/*GetVMBalloonOK Success
+
+swagger:response getVmBalloonOK
+*/
+type GetVMBalloonOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Balloon `json:"body,omitempty"`
+}
+
+// NewGetVMBalloonOK creates GetVMBalloonOK with default headers values
+func NewGetVMBalloonOK() *GetVMBalloonOK {
+
+	return &GetVMBalloonOK{}
+}
+
+// WithPayload adds the payload to the get Vm balloon o k response
+func (o *GetVMBalloonOK) WithPayload(payload *models.Balloon) *GetVMBalloonOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm balloon o k response
+func (o *GetVMBalloonOK) SetPayload(payload *models.Balloon) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMBalloonOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// GetVMBalloonNotFoundCode is the HTTP code returned for type GetVMBalloonNotFound
+const GetVMBalloonNotFoundCode int = 404
+
+/*GetVMBalloonNotFound Not Found
+
+swagger:response getVmBalloonNotFound
+*/
+type GetVMBalloonNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMBalloonNotFound creates GetVMBalloonNotFound with default headers values
+func NewGetVMBalloonNotFound() *GetVMBalloonNotFound {
+
+	return &GetVMBalloonNotFound{}
+}
+
+// WithPayload adds the payload to the get Vm balloon not found response
+func (o *GetVMBalloonNotFound) WithPayload(payload *models.StandardError) *GetVMBalloonNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm balloon not found response
+func (o *GetVMBalloonNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMBalloonNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// GetVMBalloonConflictCode is the HTTP code returned for type GetVMBalloonConflict
+const GetVMBalloonConflictCode int = 409
+
+/*GetVMBalloonConflict Conflict
+
+swagger:response getVmBalloonConflict
+*/
+type GetVMBalloonConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMBalloonConflict creates GetVMBalloonConflict with default headers values
+func NewGetVMBalloonConflict() *GetVMBalloonConflict {
+
+	return &GetVMBalloonConflict{}
+}
+
+// WithPayload adds the payload to the get Vm balloon conflict response
+func (o *GetVMBalloonConflict) WithPayload(payload *models.StandardError) *GetVMBalloonConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm balloon conflict response
+func (o *GetVMBalloonConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMBalloonConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// GetVMBalloonInternalServerErrorCode is the HTTP code returned for type GetVMBalloonInternalServerError
+const GetVMBalloonInternalServerErrorCode int = 500
+
+/*GetVMBalloonInternalServerError Internal Server Error
+
+swagger:response getVmBalloonInternalServerError
+*/
+type GetVMBalloonInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMBalloonInternalServerError creates GetVMBalloonInternalServerError with default headers values
+func NewGetVMBalloonInternalServerError() *GetVMBalloonInternalServerError {
+
+	return &GetVMBalloonInternalServerError{}
+}
+
+// WithPayload adds the payload to the get Vm balloon internal server error response
+func (o *GetVMBalloonInternalServerError) WithPayload(payload *models.StandardError) *GetVMBalloonInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm balloon internal server error response
+func (o *GetVMBalloonInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMBalloonInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
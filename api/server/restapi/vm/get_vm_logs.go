@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetVMLogsHandlerFunc turns a function with the right signature into a get Vm logs handler
+type GetVMLogsHandlerFunc func(GetVMLogsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetVMLogsHandlerFunc) Handle(params GetVMLogsParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetVMLogsHandler interface for that can handle valid get Vm logs params
+type GetVMLogsHandler interface {
+	Handle(GetVMLogsParams) middleware.Responder
+}
+
+// NewGetVMLogs creates a new http.Handler for the get Vm logs operation
+func NewGetVMLogs(ctx *middleware.Context, handler GetVMLogsHandler) *GetVMLogs {
+	return &GetVMLogs{Context: ctx, Handler: handler}
+}
+
+/* GetVMLogs swagger:route GET /vm/{id}/logs vm getVmLogs
+
+This endpoint returns the serial console output of the VM.
+
+*/
+type GetVMLogs struct {
+	Context *middleware.Context
+	Handler GetVMLogsHandler
+}
+
+func (o *GetVMLogs) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewGetVMLogsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,154 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewGetVMLogsParams creates a new GetVMLogsParams object
+// with the default values initialized.
+func NewGetVMLogsParams() GetVMLogsParams {
+
+	var (
+		// initialize parameters with default values
+
+		followDefault = bool(false)
+
+		tailDefault = int64(-1)
+	)
+
+	return GetVMLogsParams{
+		Follow: &followDefault,
+
+		Tail: &tailDefault,
+	}
+}
+
+// GetVMLogsParams contains all the bound params for the get Vm logs operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters getVmLogs
+type GetVMLogsParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Keep streaming the console output until the VM stops or the client disconnects.
+	  In: query
+	  Default: false
+	*/
+	Follow *bool
+	/*Virtual Machine ID.
+	  Required: true
+	  In: path
+	*/
+	ID string
+	/*Number of lines to return from the end of the buffered output, all buffered lines if negative.
+	  In: query
+	  Default: -1
+	*/
+	Tail *int64
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewGetVMLogsParams() beforehand.
+func (o *GetVMLogsParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qFollow, qhkFollow, _ := qs.GetOK("follow")
+	if err := o.bindFollow(qFollow, qhkFollow, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	rID, rhkID, _ := route.Params.GetOK("id")
+	if err := o.bindID(rID, rhkID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qTail, qhkTail, _ := qs.GetOK("tail")
+	if err := o.bindTail(qTail, qhkTail, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindFollow binds and validates parameter Follow from query.
+func (o *GetVMLogsParams) bindFollow(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetVMLogsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("follow", "query", "bool", raw)
+	}
+	o.Follow = &value
+
+	return nil
+}
+
+// bindID binds and validates parameter ID from path.
+func (o *GetVMLogsParams) bindID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+	o.ID = raw
+
+	return nil
+}
+
+// bindTail binds and validates parameter Tail from query.
+func (o *GetVMLogsParams) bindTail(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetVMLogsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("tail", "query", "int64", raw)
+	}
+	o.Tail = &value
+
+	return nil
+}
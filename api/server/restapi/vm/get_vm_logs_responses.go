@@ -0,0 +1,144 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// GetVMLogsOKCode is the HTTP code returned for type GetVMLogsOK
+const GetVMLogsOKCode int = 200
+
+/*GetVMLogsOK Success
+
+swagger:response getVmLogsOK
+*/
+type GetVMLogsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewGetVMLogsOK creates GetVMLogsOK with default headers values
+func NewGetVMLogsOK() *GetVMLogsOK {
+
+	return &GetVMLogsOK{}
+}
+
+// WithPayload adds the payload to the get Vm logs o k response
+func (o *GetVMLogsOK) WithPayload(payload string) *GetVMLogsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm logs o k response
+func (o *GetVMLogsOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMLogsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// GetVMLogsNotFoundCode is the HTTP code returned for type GetVMLogsNotFound
+const GetVMLogsNotFoundCode int = 404
+
+/*GetVMLogsNotFound Not Found
+
+swagger:response getVmLogsNotFound
+*/
+type GetVMLogsNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMLogsNotFound creates GetVMLogsNotFound with default headers values
+func NewGetVMLogsNotFound() *GetVMLogsNotFound {
+
+	return &GetVMLogsNotFound{}
+}
+
+// WithPayload adds the payload to the get Vm logs not found response
+func (o *GetVMLogsNotFound) WithPayload(payload *models.StandardError) *GetVMLogsNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm logs not found response
+func (o *GetVMLogsNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMLogsNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// GetVMLogsInternalServerErrorCode is the HTTP code returned for type GetVMLogsInternalServerError
+const GetVMLogsInternalServerErrorCode int = 500
+
+/*GetVMLogsInternalServerError Internal Server Error
+
+swagger:response getVmLogsInternalServerError
+*/
+type GetVMLogsInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMLogsInternalServerError creates GetVMLogsInternalServerError with default headers values
+func NewGetVMLogsInternalServerError() *GetVMLogsInternalServerError {
+
+	return &GetVMLogsInternalServerError{}
+}
+
+// WithPayload adds the payload to the get Vm logs internal server error response
+func (o *GetVMLogsInternalServerError) WithPayload(payload *models.StandardError) *GetVMLogsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm logs internal server error response
+func (o *GetVMLogsInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMLogsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetVMMetricsHandlerFunc turns a function with the right signature into a get Vm metrics handler
+type GetVMMetricsHandlerFunc func(GetVMMetricsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetVMMetricsHandlerFunc) Handle(params GetVMMetricsParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetVMMetricsHandler interface for that can handle valid get Vm metrics params
+type GetVMMetricsHandler interface {
+	Handle(GetVMMetricsParams) middleware.Responder
+}
+
+// NewGetVMMetrics creates a new http.Handler for the get Vm metrics operation
+func NewGetVMMetrics(ctx *middleware.Context, handler GetVMMetricsHandler) *GetVMMetrics {
+	return &GetVMMetrics{Context: ctx, Handler: handler}
+}
+
+/* GetVMMetrics swagger:route GET /vm/{id}/metrics vm getVmMetrics
+
+This endpoint returns the Firecracker metrics of the VM.
+
+*/
+type GetVMMetrics struct {
+	Context *middleware.Context
+	Handler GetVMMetricsHandler
+}
+
+func (o *GetVMMetrics) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewGetVMMetricsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
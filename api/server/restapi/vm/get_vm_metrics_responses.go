@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// GetVMMetricsOKCode is the HTTP code returned for type GetVMMetricsOK
+const GetVMMetricsOKCode int = 200
+
+/*GetVMMetricsOK Success
+
+swagger:response getVmMetricsOK
+*/
+type GetVMMetricsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.VMMetrics `json:"body,omitempty"`
+}
+
+// NewGetVMMetricsOK creates GetVMMetricsOK with default headers values
+func NewGetVMMetricsOK() *GetVMMetricsOK {
+
+	return &GetVMMetricsOK{}
+}
+
+// WithPayload adds the payload to the get Vm metrics o k response
+func (o *GetVMMetricsOK) WithPayload(payload models.VMMetrics) *GetVMMetricsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm metrics o k response
+func (o *GetVMMetricsOK) SetPayload(payload models.VMMetrics) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMMetricsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty map
+		payload = models.VMMetrics{}
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// GetVMMetricsNotFoundCode is the HTTP code returned for type GetVMMetricsNotFound
+const GetVMMetricsNotFoundCode int = 404
+
+/*GetVMMetricsNotFound Not Found
+
+swagger:response getVmMetricsNotFound
+*/
+type GetVMMetricsNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMMetricsNotFound creates GetVMMetricsNotFound with default headers values
+func NewGetVMMetricsNotFound() *GetVMMetricsNotFound {
+
+	return &GetVMMetricsNotFound{}
+}
+
+// WithPayload adds the payload to the get Vm metrics not found response
+func (o *GetVMMetricsNotFound) WithPayload(payload *models.StandardError) *GetVMMetricsNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm metrics not found response
+func (o *GetVMMetricsNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMMetricsNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// GetVMMetricsInternalServerErrorCode is the HTTP code returned for type GetVMMetricsInternalServerError
+const GetVMMetricsInternalServerErrorCode int = 500
+
+/*GetVMMetricsInternalServerError Internal Server Error
+
+swagger:response getVmMetricsInternalServerError
+*/
+type GetVMMetricsInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMMetricsInternalServerError creates GetVMMetricsInternalServerError with default headers values
+func NewGetVMMetricsInternalServerError() *GetVMMetricsInternalServerError {
+
+	return &GetVMMetricsInternalServerError{}
+}
+
+// WithPayload adds the payload to the get Vm metrics internal server error response
+func (o *GetVMMetricsInternalServerError) WithPayload(payload *models.StandardError) *GetVMMetricsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm metrics internal server error response
+func (o *GetVMMetricsInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMMetricsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
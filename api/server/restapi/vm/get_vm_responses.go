@@ -0,0 +1,102 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// GetVMOKCode is the HTTP code returned for type GetVMOK
+const GetVMOKCode int = 200
+
+/*GetVMOK Success
+
+swagger:response getVmOK
+*/
+type GetVMOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.VM `json:"body,omitempty"`
+}
+
+// NewGetVMOK creates GetVMOK with default headers values
+func NewGetVMOK() *GetVMOK {
+
+	return &GetVMOK{}
+}
+
+// WithPayload adds the payload to the get Vm o k response
+func (o *GetVMOK) WithPayload(payload *models.VM) *GetVMOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm o k response
+func (o *GetVMOK) SetPayload(payload *models.VM) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// GetVMNotFoundCode is the HTTP code returned for type GetVMNotFound
+const GetVMNotFoundCode int = 404
+
+/*GetVMNotFound Not Found
+
+swagger:response getVmNotFound
+*/
+type GetVMNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewGetVMNotFound creates GetVMNotFound with default headers values
+func NewGetVMNotFound() *GetVMNotFound {
+
+	return &GetVMNotFound{}
+}
+
+// WithPayload adds the payload to the get Vm not found response
+func (o *GetVMNotFound) WithPayload(payload *models.StandardError) *GetVMNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get Vm not found response
+func (o *GetVMNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
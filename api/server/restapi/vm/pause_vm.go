@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PauseVMHandlerFunc turns a function with the right signature into a pause Vm handler
+type PauseVMHandlerFunc func(PauseVMParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PauseVMHandlerFunc) Handle(params PauseVMParams) middleware.Responder {
+	return fn(params)
+}
+
+// PauseVMHandler interface for that can handle valid pause Vm params
+type PauseVMHandler interface {
+	Handle(PauseVMParams) middleware.Responder
+}
+
+// NewPauseVM creates a new http.Handler for the pause Vm operation
+func NewPauseVM(ctx *middleware.Context, handler PauseVMHandler) *PauseVM {
+	return &PauseVM{Context: ctx, Handler: handler}
+}
+
+/* PauseVM swagger:route POST /vm/{id}/pause vm pauseVm
+
+This endpoint pauses the VM keeping its state. A paused VM does not serve invocations and is not probed.
+
+*/
+type PauseVM struct {
+	Context *middleware.Context
+	Handler PauseVMHandler
+}
+
+func (o *PauseVM) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewPauseVMParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,170 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// PauseVMNoContentCode is the HTTP code returned for type PauseVMNoContent
+const PauseVMNoContentCode int = 204
+
+/*PauseVMNoContent Paused
+
+swagger:response pauseVmNoContent
+*/
+type PauseVMNoContent struct {
+}
+
+// NewPauseVMNoContent creates PauseVMNoContent with default headers values
+func NewPauseVMNoContent() *PauseVMNoContent {
+
+	return &PauseVMNoContent{}
+}
+
+// WriteResponse to the client
+func (o *PauseVMNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(204)
+}
+
+// PauseVMNotFoundCode is the HTTP code returned for type PauseVMNotFound
+const PauseVMNotFoundCode int = 404
+
+/*PauseVMNotFound Not Found
+
+swagger:response pauseVmNotFound
+*/
+type PauseVMNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPauseVMNotFound creates PauseVMNotFound with default headers values
+func NewPauseVMNotFound() *PauseVMNotFound {
+
+	return &PauseVMNotFound{}
+}
+
+// WithPayload adds the payload to the pause Vm not found response
+func (o *PauseVMNotFound) WithPayload(payload *models.StandardError) *PauseVMNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the pause Vm not found response
+func (o *PauseVMNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PauseVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PauseVMConflictCode is the HTTP code returned for type PauseVMConflict
+const PauseVMConflictCode int = 409
+
+/*PauseVMConflict Conflict
+
+swagger:response pauseVmConflict
+*/
+type PauseVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPauseVMConflict creates PauseVMConflict with default headers values
+func NewPauseVMConflict() *PauseVMConflict {
+
+	return &PauseVMConflict{}
+}
+
+// WithPayload adds the payload to the pause Vm conflict response
+func (o *PauseVMConflict) WithPayload(payload *models.StandardError) *PauseVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the pause Vm conflict response
+func (o *PauseVMConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PauseVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PauseVMInternalServerErrorCode is the HTTP code returned for type PauseVMInternalServerError
+const PauseVMInternalServerErrorCode int = 500
+
+/*PauseVMInternalServerError Internal Server Error
+
+swagger:response pauseVmInternalServerError
+*/
+type PauseVMInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPauseVMInternalServerError creates PauseVMInternalServerError with default headers values
+func NewPauseVMInternalServerError() *PauseVMInternalServerError {
+
+	return &PauseVMInternalServerError{}
+}
+
+// WithPayload adds the payload to the pause Vm internal server error response
+func (o *PauseVMInternalServerError) WithPayload(payload *models.StandardError) *PauseVMInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the pause Vm internal server error response
+func (o *PauseVMInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PauseVMInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
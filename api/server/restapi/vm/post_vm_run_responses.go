@@ -57,6 +57,50 @@ func (o *PostVMRunOK) WriteResponse(rw http.ResponseWriter, producer runtime.Pro
 	}
 }
 
+// PostVMRunBadRequestCode is the HTTP code returned for type PostVMRunBadRequest
+const PostVMRunBadRequestCode int = 400
+
+/*PostVMRunBadRequest Bad Request
+
+swagger:response postVmRunBadRequest
+*/
+type PostVMRunBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPostVMRunBadRequest creates PostVMRunBadRequest with default headers values
+func NewPostVMRunBadRequest() *PostVMRunBadRequest {
+
+	return &PostVMRunBadRequest{}
+}
+
+// WithPayload adds the payload to the post Vm run bad request response
+func (o *PostVMRunBadRequest) WithPayload(payload *models.StandardError) *PostVMRunBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post Vm run bad request response
+func (o *PostVMRunBadRequest) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostVMRunBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // PostVMRunInternalServerErrorCode is the HTTP code returned for type PostVMRunInternalServerError
 const PostVMRunInternalServerErrorCode int = 500
 
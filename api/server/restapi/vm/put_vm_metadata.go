@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PutVMMetadataHandlerFunc turns a function with the right signature into a put Vm metadata handler
+type PutVMMetadataHandlerFunc func(PutVMMetadataParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PutVMMetadataHandlerFunc) Handle(params PutVMMetadataParams) middleware.Responder {
+	return fn(params)
+}
+
+// PutVMMetadataHandler interface for that can handle valid put Vm metadata params
+type PutVMMetadataHandler interface {
+	Handle(PutVMMetadataParams) middleware.Responder
+}
+
+// NewPutVMMetadata creates a new http.Handler for the put Vm metadata operation
+func NewPutVMMetadata(ctx *middleware.Context, handler PutVMMetadataHandler) *PutVMMetadata {
+	return &PutVMMetadata{Context: ctx, Handler: handler}
+}
+
+/* PutVMMetadata swagger:route PUT /vm/{id}/metadata vm putVmMetadata
+
+This endpoint replaces the metadata JSON of the VM in the MMDS, the standard keys under "firebox" are kept.
+
+*/
+type PutVMMetadata struct {
+	Context *middleware.Context
+	Handler PutVMMetadataHandler
+}
+
+func (o *PutVMMetadata) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewPutVMMetadataParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,170 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// PutVMMetadataNoContentCode is the HTTP code returned for type PutVMMetadataNoContent
+const PutVMMetadataNoContentCode int = 204
+
+/*PutVMMetadataNoContent Updated
+
+swagger:response putVmMetadataNoContent
+*/
+type PutVMMetadataNoContent struct {
+}
+
+// NewPutVMMetadataNoContent creates PutVMMetadataNoContent with default headers values
+func NewPutVMMetadataNoContent() *PutVMMetadataNoContent {
+
+	return &PutVMMetadataNoContent{}
+}
+
+// WriteResponse to the client
+func (o *PutVMMetadataNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(204)
+}
+
+// PutVMMetadataNotFoundCode is the HTTP code returned for type PutVMMetadataNotFound
+const PutVMMetadataNotFoundCode int = 404
+
+/*PutVMMetadataNotFound Not Found
+
+swagger:response putVmMetadataNotFound
+*/
+type PutVMMetadataNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPutVMMetadataNotFound creates PutVMMetadataNotFound with default headers values
+func NewPutVMMetadataNotFound() *PutVMMetadataNotFound {
+
+	return &PutVMMetadataNotFound{}
+}
+
+// WithPayload adds the payload to the put Vm metadata not found response
+func (o *PutVMMetadataNotFound) WithPayload(payload *models.StandardError) *PutVMMetadataNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put Vm metadata not found response
+func (o *PutVMMetadataNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutVMMetadataNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PutVMMetadataConflictCode is the HTTP code returned for type PutVMMetadataConflict
+const PutVMMetadataConflictCode int = 409
+
+/*PutVMMetadataConflict Conflict
+
+swagger:response putVmMetadataConflict
+*/
+type PutVMMetadataConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPutVMMetadataConflict creates PutVMMetadataConflict with default headers values
+func NewPutVMMetadataConflict() *PutVMMetadataConflict {
+
+	return &PutVMMetadataConflict{}
+}
+
+// WithPayload adds the payload to the put Vm metadata conflict response
+func (o *PutVMMetadataConflict) WithPayload(payload *models.StandardError) *PutVMMetadataConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put Vm metadata conflict response
+func (o *PutVMMetadataConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutVMMetadataConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PutVMMetadataInternalServerErrorCode is the HTTP code returned for type PutVMMetadataInternalServerError
+const PutVMMetadataInternalServerErrorCode int = 500
+
+/*PutVMMetadataInternalServerError Internal Server Error
+
+swagger:response putVmMetadataInternalServerError
+*/
+type PutVMMetadataInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewPutVMMetadataInternalServerError creates PutVMMetadataInternalServerError with default headers values
+func NewPutVMMetadataInternalServerError() *PutVMMetadataInternalServerError {
+
+	return &PutVMMetadataInternalServerError{}
+}
+
+// WithPayload adds the payload to the put Vm metadata internal server error response
+func (o *PutVMMetadataInternalServerError) WithPayload(payload *models.StandardError) *PutVMMetadataInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put Vm metadata internal server error response
+func (o *PutVMMetadataInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutVMMetadataInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
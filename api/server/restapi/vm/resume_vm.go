@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ResumeVMHandlerFunc turns a function with the right signature into a resume Vm handler
+type ResumeVMHandlerFunc func(ResumeVMParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ResumeVMHandlerFunc) Handle(params ResumeVMParams) middleware.Responder {
+	return fn(params)
+}
+
+// ResumeVMHandler interface for that can handle valid resume Vm params
+type ResumeVMHandler interface {
+	Handle(ResumeVMParams) middleware.Responder
+}
+
+// NewResumeVM creates a new http.Handler for the resume Vm operation
+func NewResumeVM(ctx *middleware.Context, handler ResumeVMHandler) *ResumeVM {
+	return &ResumeVM{Context: ctx, Handler: handler}
+}
+
+/* ResumeVM swagger:route POST /vm/{id}/resume vm resumeVm
+
+This endpoint resumes a paused VM.
+
+*/
+type ResumeVM struct {
+	Context *middleware.Context
+	Handler ResumeVMHandler
+}
+
+func (o *ResumeVM) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewResumeVMParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
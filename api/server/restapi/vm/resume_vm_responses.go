@@ -0,0 +1,170 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// ResumeVMNoContentCode is the HTTP code returned for type ResumeVMNoContent
+const ResumeVMNoContentCode int = 204
+
+/*ResumeVMNoContent Resumed
+
+swagger:response resumeVmNoContent
+*/
+type ResumeVMNoContent struct {
+}
+
+// NewResumeVMNoContent creates ResumeVMNoContent with default headers values
+func NewResumeVMNoContent() *ResumeVMNoContent {
+
+	return &ResumeVMNoContent{}
+}
+
+// WriteResponse to the client
+func (o *ResumeVMNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(204)
+}
+
+// ResumeVMNotFoundCode is the HTTP code returned for type ResumeVMNotFound
+const ResumeVMNotFoundCode int = 404
+
+/*ResumeVMNotFound Not Found
+
+swagger:response resumeVmNotFound
+*/
+type ResumeVMNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewResumeVMNotFound creates ResumeVMNotFound with default headers values
+func NewResumeVMNotFound() *ResumeVMNotFound {
+
+	return &ResumeVMNotFound{}
+}
+
+// WithPayload adds the payload to the resume Vm not found response
+func (o *ResumeVMNotFound) WithPayload(payload *models.StandardError) *ResumeVMNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the resume Vm not found response
+func (o *ResumeVMNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResumeVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// ResumeVMConflictCode is the HTTP code returned for type ResumeVMConflict
+const ResumeVMConflictCode int = 409
+
+/*ResumeVMConflict Conflict
+
+swagger:response resumeVmConflict
+*/
+type ResumeVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewResumeVMConflict creates ResumeVMConflict with default headers values
+func NewResumeVMConflict() *ResumeVMConflict {
+
+	return &ResumeVMConflict{}
+}
+
+// WithPayload adds the payload to the resume Vm conflict response
+func (o *ResumeVMConflict) WithPayload(payload *models.StandardError) *ResumeVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the resume Vm conflict response
+func (o *ResumeVMConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResumeVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// ResumeVMInternalServerErrorCode is the HTTP code returned for type ResumeVMInternalServerError
+const ResumeVMInternalServerErrorCode int = 500
+
+/*ResumeVMInternalServerError Internal Server Error
+
+swagger:response resumeVmInternalServerError
+*/
+type ResumeVMInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewResumeVMInternalServerError creates ResumeVMInternalServerError with default headers values
+func NewResumeVMInternalServerError() *ResumeVMInternalServerError {
+
+	return &ResumeVMInternalServerError{}
+}
+
+// WithPayload adds the payload to the resume Vm internal server error response
+func (o *ResumeVMInternalServerError) WithPayload(payload *models.StandardError) *ResumeVMInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the resume Vm internal server error response
+func (o *ResumeVMInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResumeVMInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// UpdateVMBalloonHandlerFunc turns a function with the right signature into a update Vm balloon handler
+type UpdateVMBalloonHandlerFunc func(UpdateVMBalloonParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn UpdateVMBalloonHandlerFunc) Handle(params UpdateVMBalloonParams) middleware.Responder {
+	return fn(params)
+}
+
+// UpdateVMBalloonHandler interface for that can handle valid update Vm balloon params
+type UpdateVMBalloonHandler interface {
+	Handle(UpdateVMBalloonParams) middleware.Responder
+}
+
+// NewUpdateVMBalloon creates a new http.Handler for the update Vm balloon operation
+func NewUpdateVMBalloon(ctx *middleware.Context, handler UpdateVMBalloonHandler) *UpdateVMBalloon {
+	return &UpdateVMBalloon{Context: ctx, Handler: handler}
+}
+
+/* UpdateVMBalloon swagger:route PATCH /vm/{id}/balloon vm updateVmBalloon
+
+This endpoint inflates or deflates the balloon of the VM.
+
+*/
+type UpdateVMBalloon struct {
+	Context *middleware.Context
+	Handler UpdateVMBalloonHandler
+}
+
+func (o *UpdateVMBalloon) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewUpdateVMBalloonParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
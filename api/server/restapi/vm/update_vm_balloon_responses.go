@@ -0,0 +1,214 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package vm
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// UpdateVMBalloonNoContentCode is the HTTP code returned for type UpdateVMBalloonNoContent
+const UpdateVMBalloonNoContentCode int = 204
+
+/*UpdateVMBalloonNoContent Updated
+
+swagger:response updateVmBalloonNoContent
+*/
+type UpdateVMBalloonNoContent struct {
+}
+
+// NewUpdateVMBalloonNoContent creates UpdateVMBalloonNoContent with default headers values
+func NewUpdateVMBalloonNoContent() *UpdateVMBalloonNoContent {
+
+	return &UpdateVMBalloonNoContent{}
+}
+
+// WriteResponse to the client
+func (o *UpdateVMBalloonNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(204)
+}
+
+// UpdateVMBalloonBadRequestCode is the HTTP code returned for type UpdateVMBalloonBadRequest
+const UpdateVMBalloonBadRequestCode int = 400
+
+/*UpdateVMBalloonBadRequest Bad Request
+
+swagger:response updateVmBalloonBadRequest
+*/
+type UpdateVMBalloonBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewUpdateVMBalloonBadRequest creates UpdateVMBalloonBadRequest with default headers values
+func NewUpdateVMBalloonBadRequest() *UpdateVMBalloonBadRequest {
+
+	return &UpdateVMBalloonBadRequest{}
+}
+
+// WithPayload adds the payload to the update Vm balloon bad request response
+func (o *UpdateVMBalloonBadRequest) WithPayload(payload *models.StandardError) *UpdateVMBalloonBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update Vm balloon bad request response
+func (o *UpdateVMBalloonBadRequest) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateVMBalloonBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// UpdateVMBalloonNotFoundCode is the HTTP code returned for type UpdateVMBalloonNotFound
+const UpdateVMBalloonNotFoundCode int = 404
+
+/*UpdateVMBalloonNotFound Not Found
+
+swagger:response updateVmBalloonNotFound
+*/
+type UpdateVMBalloonNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewUpdateVMBalloonNotFound creates UpdateVMBalloonNotFound with default headers values
+func NewUpdateVMBalloonNotFound() *UpdateVMBalloonNotFound {
+
+	return &UpdateVMBalloonNotFound{}
+}
+
+// WithPayload adds the payload to the update Vm balloon not found response
+func (o *UpdateVMBalloonNotFound) WithPayload(payload *models.StandardError) *UpdateVMBalloonNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update Vm balloon not found response
+func (o *UpdateVMBalloonNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateVMBalloonNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// UpdateVMBalloonConflictCode is the HTTP code returned for type UpdateVMBalloonConflict
+const UpdateVMBalloonConflictCode int = 409
+
+/*UpdateVMBalloonConflict Conflict
+
+swagger:response updateVmBalloonConflict
+*/
+type UpdateVMBalloonConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewUpdateVMBalloonConflict creates UpdateVMBalloonConflict with default headers values
+func NewUpdateVMBalloonConflict() *UpdateVMBalloonConflict {
+
+	return &UpdateVMBalloonConflict{}
+}
+
+// WithPayload adds the payload to the update Vm balloon conflict response
+func (o *UpdateVMBalloonConflict) WithPayload(payload *models.StandardError) *UpdateVMBalloonConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update Vm balloon conflict response
+func (o *UpdateVMBalloonConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateVMBalloonConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// UpdateVMBalloonInternalServerErrorCode is the HTTP code returned for type UpdateVMBalloonInternalServerError
+const UpdateVMBalloonInternalServerErrorCode int = 500
+
+/*UpdateVMBalloonInternalServerError Internal Server Error
+
+swagger:response updateVmBalloonInternalServerError
+*/
+type UpdateVMBalloonInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewUpdateVMBalloonInternalServerError creates UpdateVMBalloonInternalServerError with default headers values
+func NewUpdateVMBalloonInternalServerError() *UpdateVMBalloonInternalServerError {
+
+	return &UpdateVMBalloonInternalServerError{}
+}
+
+// WithPayload adds the payload to the update Vm balloon internal server error response
+func (o *UpdateVMBalloonInternalServerError) WithPayload(payload *models.StandardError) *UpdateVMBalloonInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the update Vm balloon internal server error response
+func (o *UpdateVMBalloonInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UpdateVMBalloonInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
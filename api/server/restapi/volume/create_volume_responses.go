@@ -0,0 +1,190 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package volume
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// CreateVolumeCreatedCode is the HTTP code returned for type CreateVolumeCreated
+const CreateVolumeCreatedCode int = 201
+
+/*CreateVolumeCreated Created
+
+swagger:response createVolumeCreated
+*/
+type CreateVolumeCreated struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Volume `json:"body,omitempty"`
+}
+
+// NewCreateVolumeCreated creates CreateVolumeCreated with default headers values
+func NewCreateVolumeCreated() *CreateVolumeCreated {
+
+	return &CreateVolumeCreated{}
+}
+
+// WithPayload adds the payload to the create volume created response
+func (o *CreateVolumeCreated) WithPayload(payload *models.Volume) *CreateVolumeCreated {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create volume created response
+func (o *CreateVolumeCreated) SetPayload(payload *models.Volume) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateVolumeCreated) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(201)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CreateVolumeBadRequestCode is the HTTP code returned for type CreateVolumeBadRequest
+const CreateVolumeBadRequestCode int = 400
+
+/*CreateVolumeBadRequest Bad Request
+
+swagger:response createVolumeBadRequest
+*/
+type CreateVolumeBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewCreateVolumeBadRequest creates CreateVolumeBadRequest with default headers values
+func NewCreateVolumeBadRequest() *CreateVolumeBadRequest {
+
+	return &CreateVolumeBadRequest{}
+}
+
+// WithPayload adds the payload to the create volume bad request response
+func (o *CreateVolumeBadRequest) WithPayload(payload *models.StandardError) *CreateVolumeBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create volume bad request response
+func (o *CreateVolumeBadRequest) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateVolumeBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CreateVolumeConflictCode is the HTTP code returned for type CreateVolumeConflict
+const CreateVolumeConflictCode int = 409
+
+/*CreateVolumeConflict Conflict
+
+swagger:response createVolumeConflict
+*/
+type CreateVolumeConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewCreateVolumeConflict creates CreateVolumeConflict with default headers values
+func NewCreateVolumeConflict() *CreateVolumeConflict {
+
+	return &CreateVolumeConflict{}
+}
+
+// WithPayload adds the payload to the create volume conflict response
+func (o *CreateVolumeConflict) WithPayload(payload *models.StandardError) *CreateVolumeConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create volume conflict response
+func (o *CreateVolumeConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateVolumeConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CreateVolumeInternalServerErrorCode is the HTTP code returned for type CreateVolumeInternalServerError
+const CreateVolumeInternalServerErrorCode int = 500
+
+/*CreateVolumeInternalServerError Internal Server Error
+
+swagger:response createVolumeInternalServerError
+*/
+type CreateVolumeInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewCreateVolumeInternalServerError creates CreateVolumeInternalServerError with default headers values
+func NewCreateVolumeInternalServerError() *CreateVolumeInternalServerError {
+
+	return &CreateVolumeInternalServerError{}
+}
+
+// WithPayload adds the payload to the create volume internal server error response
+func (o *CreateVolumeInternalServerError) WithPayload(payload *models.StandardError) *CreateVolumeInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create volume internal server error response
+func (o *CreateVolumeInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateVolumeInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
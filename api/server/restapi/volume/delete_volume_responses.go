@@ -0,0 +1,170 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package volume
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// DeleteVolumeNoContentCode is the HTTP code returned for type DeleteVolumeNoContent
+const DeleteVolumeNoContentCode int = 204
+
+/*DeleteVolumeNoContent Deleted
+
+swagger:response deleteVolumeNoContent
+*/
+type DeleteVolumeNoContent struct {
+}
+
+// NewDeleteVolumeNoContent creates DeleteVolumeNoContent with default headers values
+func NewDeleteVolumeNoContent() *DeleteVolumeNoContent {
+
+	return &DeleteVolumeNoContent{}
+}
+
+// WriteResponse to the client
+func (o *DeleteVolumeNoContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(204)
+}
+
+// DeleteVolumeNotFoundCode is the HTTP code returned for type DeleteVolumeNotFound
+const DeleteVolumeNotFoundCode int = 404
+
+/*DeleteVolumeNotFound Not Found
+
+swagger:response deleteVolumeNotFound
+*/
+type DeleteVolumeNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewDeleteVolumeNotFound creates DeleteVolumeNotFound with default headers values
+func NewDeleteVolumeNotFound() *DeleteVolumeNotFound {
+
+	return &DeleteVolumeNotFound{}
+}
+
+// WithPayload adds the payload to the delete volume not found response
+func (o *DeleteVolumeNotFound) WithPayload(payload *models.StandardError) *DeleteVolumeNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete volume not found response
+func (o *DeleteVolumeNotFound) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteVolumeNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DeleteVolumeConflictCode is the HTTP code returned for type DeleteVolumeConflict
+const DeleteVolumeConflictCode int = 409
+
+/*DeleteVolumeConflict Conflict
+
+swagger:response deleteVolumeConflict
+*/
+type DeleteVolumeConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewDeleteVolumeConflict creates DeleteVolumeConflict with default headers values
+func NewDeleteVolumeConflict() *DeleteVolumeConflict {
+
+	return &DeleteVolumeConflict{}
+}
+
+// WithPayload adds the payload to the delete volume conflict response
+func (o *DeleteVolumeConflict) WithPayload(payload *models.StandardError) *DeleteVolumeConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete volume conflict response
+func (o *DeleteVolumeConflict) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteVolumeConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DeleteVolumeInternalServerErrorCode is the HTTP code returned for type DeleteVolumeInternalServerError
+const DeleteVolumeInternalServerErrorCode int = 500
+
+/*DeleteVolumeInternalServerError Internal Server Error
+
+swagger:response deleteVolumeInternalServerError
+*/
+type DeleteVolumeInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewDeleteVolumeInternalServerError creates DeleteVolumeInternalServerError with default headers values
+func NewDeleteVolumeInternalServerError() *DeleteVolumeInternalServerError {
+
+	return &DeleteVolumeInternalServerError{}
+}
+
+// WithPayload adds the payload to the delete volume internal server error response
+func (o *DeleteVolumeInternalServerError) WithPayload(payload *models.StandardError) *DeleteVolumeInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete volume internal server error response
+func (o *DeleteVolumeInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteVolumeInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
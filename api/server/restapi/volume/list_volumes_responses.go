@@ -0,0 +1,105 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package volume
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/combust-labs/firebox/api/models"
+)
+
+// ListVolumesOKCode is the HTTP code returned for type ListVolumesOK
+const ListVolumesOKCode int = 200
+
+/*ListVolumesOK Success
+
+swagger:response listVolumesOK
+*/
+type ListVolumesOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload []*models.Volume `json:"body,omitempty"`
+}
+
+// NewListVolumesOK creates ListVolumesOK with default headers values
+func NewListVolumesOK() *ListVolumesOK {
+
+	return &ListVolumesOK{}
+}
+
+// WithPayload adds the payload to the list volumes o k response
+func (o *ListVolumesOK) WithPayload(payload []*models.Volume) *ListVolumesOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list volumes o k response
+func (o *ListVolumesOK) SetPayload(payload []*models.Volume) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListVolumesOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = make([]*models.Volume, 0, 50)
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// ListVolumesInternalServerErrorCode is the HTTP code returned for type ListVolumesInternalServerError
+const ListVolumesInternalServerErrorCode int = 500
+
+/*ListVolumesInternalServerError Internal Server Error
+
+swagger:response listVolumesInternalServerError
+*/
+type ListVolumesInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.StandardError `json:"body,omitempty"`
+}
+
+// NewListVolumesInternalServerError creates ListVolumesInternalServerError with default headers values
+func NewListVolumesInternalServerError() *ListVolumesInternalServerError {
+
+	return &ListVolumesInternalServerError{}
+}
+
+// WithPayload adds the payload to the list volumes internal server error response
+func (o *ListVolumesInternalServerError) WithPayload(payload *models.StandardError) *ListVolumesInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list volumes internal server error response
+func (o *ListVolumesInternalServerError) SetPayload(payload *models.StandardError) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListVolumesInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
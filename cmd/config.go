@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/combust-labs/firebox/pkg/network"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// loadServicesConfig reads the per service configuration from the "services" section of the config file
+func loadServicesConfig() error {
+	if err := viper.UnmarshalKey("services", &vmmConfig.Services); err != nil {
+		return err
+	}
+	for name, svc := range vmmConfig.Services {
+		if svc.NetworkPolicy == nil {
+			continue
+		}
+		if err := network.ValidatePolicy(*svc.NetworkPolicy); err != nil {
+			return errors.Wrapf(err, "network policy of service '%s'", name)
+		}
+	}
+	return nil
+}
+
+// loadProfilesConfig reads the boot profiles from the "profiles" section of the config file
+func loadProfilesConfig() error {
+	return viper.UnmarshalKey("profiles", &vmmConfig.Profiles)
+}
+
+// loadBridgesConfig reads the additional bridges of the built-in network from the "bridges" section of the config file
+func loadBridgesConfig() error {
+	if err := viper.UnmarshalKey("bridges", &vmmConfig.Network.Bridges); err != nil {
+		return err
+	}
+	for name, bridge := range vmmConfig.Network.Bridges {
+		if name == vmmConfig.Network.Bridge.Name {
+			return errors.Errorf("bridge '%s' is configured by the flags already", name)
+		}
+		bridge.Name = name
+		vmmConfig.Network.Bridges[name] = bridge
+	}
+	return nil
+}
+
+// cniNetworks returns the CNI networks of the default interface and of the interfaces of the services
+func cniNetworks() []string {
+	networks := []string{vmmConfig.Network.CNI.NetworkName}
+	seen := map[string]bool{vmmConfig.Network.CNI.NetworkName: true}
+	for _, svc := range vmmConfig.Services {
+		for _, iface := range svc.Interfaces {
+			if !seen[iface.Network] {
+				seen[iface.Network] = true
+				networks = append(networks, iface.Network)
+			}
+		}
+	}
+	return networks
+}
+
+// initBridges sets up the bridges of the built-in network used when CNI is disabled,
+// the returned IPAMs allocate the guest IPs by bridge name
+func initBridges(logger *log.Logger) (map[string]*network.IPAM, error) {
+	if err := loadBridgesConfig(); err != nil {
+		return nil, errors.Wrap(err, "loading bridges failed")
+	}
+	bridges := []config.BridgeConfig{vmmConfig.Network.Bridge}
+	for _, bridge := range vmmConfig.Network.Bridges {
+		bridges = append(bridges, bridge)
+	}
+	result := make(map[string]*network.IPAM, len(bridges))
+	for _, bridge := range bridges {
+		if err := network.EnsureBridge(bridge); err != nil {
+			return nil, err
+		}
+		logger.Infof("Built-in network on bridge %s with subnet %s", bridge.Name, bridge.Subnet)
+		ipam, err := network.NewIPAM(bridge, filepath.Join(vmmConfig.WorkDir, "network"))
+		if err != nil {
+			return nil, err
+		}
+		result[bridge.Name] = ipam
+	}
+	return result, nil
+}
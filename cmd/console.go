@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Ctrl-] like telnet
+const consoleDetachKey = 0x1d
+
+type ConsoleConfig struct {
+	ServerURL string
+}
+
+var (
+	consoleConfig = new(ConsoleConfig)
+)
+
+// consoleCmd represents the console command
+var consoleCmd = &cobra.Command{
+	Use:   "console <vmid>",
+	Short: "Attach to the serial console of a VM started by the server",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runConsole(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(consoleCmd)
+
+	consoleCmd.Flags().StringVar(&consoleConfig.ServerURL, "server-url", "http://localhost:8080", "URL of the firebox server")
+}
+
+func runConsole(vmid string) {
+	logger := newLogger()
+
+	consoleURL, err := getConsoleURL(consoleConfig.ServerURL, vmid)
+	if err != nil {
+		logger.Fatalf("invalid server URL: %v", err)
+	}
+	conn, resp, err := websocket.DefaultDialer.Dial(consoleURL, nil)
+	if err != nil {
+		if resp != nil {
+			err = errors.Wrapf(err, "server responded with %s", resp.Status)
+		}
+		logger.Fatalf("attaching to console of vmid %s failed: %v", vmid, err)
+	}
+	defer conn.Close()
+
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			logger.Fatalf("setting terminal raw mode failed: %v", err)
+		}
+		defer func() {
+			_ = term.Restore(fd, state)
+		}()
+	}
+	fmt.Fprintf(os.Stderr, "Attached to console of vmid %s, press Ctrl-] to detach\r\n", vmid)
+
+	detached := make(chan struct{})
+	go func() {
+		defer close(detached)
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			data := buf[:n]
+			i := bytes.IndexByte(data, consoleDetachKey)
+			if i >= 0 {
+				data = data[:i]
+			}
+			if len(data) > 0 {
+				if err := conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+					return
+				}
+			}
+			if i >= 0 {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+		}
+	}()
+
+	closed := make(chan error, 1)
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				closed <- err
+				return
+			}
+			_, _ = os.Stdout.Write(data)
+		}
+	}()
+
+	select {
+	case <-detached:
+		fmt.Fprintf(os.Stderr, "\r\nDetached from console of vmid %s\r\n", vmid)
+	case err := <-closed:
+		fmt.Fprintf(os.Stderr, "\r\nConsole of vmid %s closed: %v\r\n", vmid, err)
+	}
+}
+
+func getConsoleURL(serverURL, vmid string) (string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "http", "":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", errors.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	u.Path = path.Join(u.Path, "vm", url.PathEscape(vmid), "console")
+	return u.String(), nil
+}
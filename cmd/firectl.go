@@ -2,13 +2,31 @@ package cmd
 
 import (
 	"context"
+	"github.com/combust-labs/firebox/config"
 	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/combust-labs/firebox/pkg/network"
+	"github.com/combust-labs/firebox/pkg/snapshot"
 	"github.com/combust-labs/firebox/pkg/vmm"
 	"github.com/oklog/run"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
+)
+
+type FirectlConfig struct {
+	Profile             string
+	Snapshot            string
+	SnapshotCreate      string
+	SnapshotCreateDelay time.Duration
+}
+
+var (
+	firectlConfig = new(FirectlConfig)
 )
 
 // firectlCmd represents the firectl command
@@ -23,6 +41,11 @@ var firectlCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(firectlCmd)
 	initVMMConfigFlags(firectlCmd)
+	firectlCmd.Flags().BoolVar(&vmmConfig.Console.Attach, "console-attach", true, "Attach the VM serial console to stdin and stdout")
+	firectlCmd.Flags().StringVar(&firectlConfig.Profile, "profile", "", "Boot the VM with the named boot profile of the config file")
+	firectlCmd.Flags().StringVar(&firectlConfig.Snapshot, "snapshot", "", "Start the VM from the named snapshot in the work dir instead of booting it")
+	firectlCmd.Flags().StringVar(&firectlConfig.SnapshotCreate, "snapshot-create", "", "Create a snapshot of the VM with the given name once --snapshot-create-delay passed")
+	firectlCmd.Flags().DurationVar(&firectlConfig.SnapshotCreateDelay, "snapshot-create-delay", 10*time.Second, "Time after the VM start until the snapshot is created")
 }
 
 func firectlRun() {
@@ -32,6 +55,61 @@ func firectlRun() {
 	shutdownCtx, shutdownFunc := context.WithCancel(ctx)
 	defer shutdownFunc()
 
+	snapshots, err := snapshot.NewStore(filepath.Join(vmmConfig.WorkDir, "snapshots"))
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+	if firectlConfig.Profile != "" {
+		if err := loadProfilesConfig(); err != nil {
+			logger.Fatalf("loading boot profiles failed: %v", err)
+		}
+		p, ok := vmmConfig.Profiles[firectlConfig.Profile]
+		if !ok {
+			logger.Fatalf("boot profile '%s' not found", firectlConfig.Profile)
+		}
+		config.ApplyBootProfile(vmmConfig, p)
+	}
+	if firectlConfig.Snapshot != "" {
+		s, err := snapshots.Get(firectlConfig.Snapshot)
+		if err != nil {
+			logger.Fatalf("%v", err)
+		}
+		vmmConfig.Restore = s.RestoreConfig()
+		vmmConfig.Drives = vmmConfig.Restore.Drives
+	}
+	if vmmConfig.Restore != nil {
+		// the restored guest keeps the interfaces of the snapshot
+		vmmConfig.Network.Interfaces = config.RestoreInterfaces(vmmConfig)
+	}
+	var bridges map[string]*network.IPAM
+	// owners are the VMIDs the leased IPs are bound to
+	owners := make(map[string]string)
+	if !vmmConfig.Network.CNI.Enable {
+		if bridges, err = initBridges(logger); err != nil {
+			logger.Fatalf("%v", err)
+		}
+		if len(vmmConfig.Network.Interfaces) == 0 {
+			vmmConfig.Network.Interfaces = []config.InterfaceConfig{config.DefaultInterface(vmmConfig)}
+		}
+		for i := range vmmConfig.Network.Interfaces {
+			iface := &vmmConfig.Network.Interfaces[i]
+			ipam, ok := bridges[iface.Network]
+			if !ok {
+				logger.Fatalf("bridge '%s' is not configured", iface.Network)
+			}
+			ip, err := leaseIP(ipam, iface.IP)
+			if err != nil {
+				logger.Fatalf("%v", err)
+			}
+			defer func() {
+				if err := ipam.Release(ip, owners[ip.String()]); err != nil {
+					logger.Errorf("releasing IP %v failed: %v", ip, err)
+				}
+			}()
+			iface.IP = ip.String()
+		}
+	}
+
 	var g run.Group
 	{
 		g.Add(func() error {
@@ -44,13 +122,25 @@ func firectlRun() {
 		})
 	}
 	{
-		control := vmm.NewVMM(logger, *vmmConfig)
+		control := vmm.NewVMM(logger, *vmmConfig, nil)
+		for _, iface := range vmmConfig.Network.Interfaces {
+			if ipam := bridges[iface.Network]; ipam != nil {
+				if err := ipam.Bind(net.ParseIP(iface.IP), control.GetID()); err != nil {
+					logger.Errorf("%v", err)
+					continue
+				}
+				owners[iface.IP] = control.GetID()
+			}
+		}
 		g.Add(func() error {
 			err := control.Start()
 			if err != nil {
 				return err
 			}
 			logger.Infof("VMM IP %v", control.GetIP())
+			if firectlConfig.SnapshotCreate != "" {
+				go createSnapshot(shutdownCtx, logger, snapshots, control)
+			}
 			return control.WaitFinished()
 		}, func(err error) {
 			defer shutdownFunc()
@@ -64,6 +154,33 @@ func firectlRun() {
 	logger.Infof("Finished with %v", g.Run())
 }
 
+// leaseIP leases a guest IP on a bridge of the built-in network, the configured guest IP is reserved, e.g. the IP
+// of the snapshot a VM is started from
+func leaseIP(ipam *network.IPAM, guestIP string) (net.IP, error) {
+	if guestIP == "" {
+		return ipam.Allocate()
+	}
+	ip := net.ParseIP(guestIP)
+	if ip == nil {
+		return nil, errors.Errorf("invalid guest IP '%s'", guestIP)
+	}
+	return ip, ipam.Reserve(ip)
+}
+
+func createSnapshot(ctx context.Context, logger *log.Logger, snapshots *snapshot.Store, control vmm.VMM) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(firectlConfig.SnapshotCreateDelay):
+	}
+	s, err := snapshots.Create(firectlConfig.SnapshotCreate, control.GetID(), "", control.Snapshot)
+	if err != nil {
+		logger.Errorf("creating snapshot failed: %v", err)
+		return
+	}
+	logger.Infof("Created snapshot %s in %s", s.Name, filepath.Join(vmmConfig.WorkDir, "snapshots", s.Name))
+}
+
 func waitForSignal(ctx context.Context, logger *log.Logger) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
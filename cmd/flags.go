@@ -15,15 +15,23 @@ var (
 func initVMMConfigFlags(cmd *cobra.Command) {
 	cmd.Flags().DurationVar(&vmmConfig.VMM.ShutdownTimeout, "shutdown-timeout", 30*time.Second, "Shutdown timeout before VMM is stopped forcefully")
 
-	cmd.Flags().StringVar(&vmmConfig.RootFS, "rootfs", "./image.ext4", "Path to root disk image")
+	cmd.Flags().StringVar(&vmmConfig.RootFS, "rootfs", "./image.ext4", "Path to root disk image, each VM boots from its own copy of the image")
+	cmd.Flags().BoolVar(&vmmConfig.KeepRootFS, "keep-rootfs", false, "Keep the work dir with the rootfs copy of a VM after it stopped")
 	cmd.Flags().StringVar(&vmmConfig.KernelImage, "kernel-image", "./vmlinux", "Path to the kernel image")
+	cmd.Flags().StringVar(&vmmConfig.Initrd, "initrd", "", "Path to the initrd image, none by default")
 	cmd.Flags().StringVar(&vmmConfig.KernelArgs, "kernel-args", "console=ttyS0 noapic reboot=k panic=1 pci=off nomodules rw", "The command-line arguments that should be passed to the kernel.")
-	cmd.Flags().StringVar(&vmmConfig.NetNS, "net-ns", "", "Network namespace")
+	cmd.Flags().StringVar(&vmmConfig.NetNS, "net-ns", "", "Network namespace path of the VM, by default each VM on CNI gets its own network namespace in --net-ns-dir")
+	cmd.Flags().StringVar(&vmmConfig.NetNSDir, "net-ns-dir", "/var/run/netns", "Directory of the network namespaces created for the VMs")
+	cmd.Flags().StringVar(&vmmConfig.WorkDir, "work-dir", "/var/lib/firebox", "Directory for per VM runtime files like the Firecracker log and metrics FIFOs")
 
 	cmd.Flags().StringVar(&vmmConfig.SocketPath, "socket-path", "", "Path to use for firecracker socket, defaults to a unique file in in the first existing directory from {$HOME, $TMPDIR, or /tmp}")
 	cmd.Flags().StringVar(&vmmConfig.LogLevel, "machine-log-level", models.LoggerLevelDebug, "Verbosity of Firecracker logging.  One of: Debug, Info, Warning or Error")
 	cmd.Flags().BoolVar(&vmmConfig.DebugClient, "machine-debug-client", false, "Debug firecracker HTTP calls. Requires machine log level debug.")
 
+	cmd.Flags().IntVar(&vmmConfig.Console.BufferSize, "console-buffer-size", 64*1024, "Size in bytes of the in-memory buffer keeping the latest serial console output of each VM")
+	cmd.Flags().Int64Var(&vmmConfig.Console.LogMaxSize, "console-log-max-size", 10*1024*1024, "Size in bytes after which the serial console log file of a VM is rotated")
+	cmd.Flags().IntVar(&vmmConfig.Console.LogMaxFiles, "console-log-max-files", 3, "Number of rotated serial console log files to keep per VM")
+
 	cmd.Flags().BoolVar(&vmmConfig.Network.AllowMMDS, "mmds", false, "Activate the microVM Metadata Service")
 
 	cmd.Flags().StringVar(&vmmConfig.Network.CNI.BinDir, "cni-bin-dir", "/opt/cni/bin", "CNI plugins binaries directory")
@@ -34,6 +42,12 @@ func initVMMConfigFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&vmmConfig.Network.CNI.NetworkName, "cni-network-name", "firebox", "Name in the Network Configuration List")
 	cmd.Flags().StringVar(&vmmConfig.Network.CNI.IfaceName, "cni-iface-name", "", "Network interface name")
 
+	cmd.Flags().StringVar(&vmmConfig.Network.Bridge.Name, "bridge-name", "fireboxbr0", "Bridge of the built-in network used when CNI is disabled")
+	cmd.Flags().StringVar(&vmmConfig.Network.Bridge.Subnet, "bridge-subnet", "192.168.127.0/24", "Subnet of the built-in network, the bridge gets the first address")
+	cmd.Flags().StringVar(&vmmConfig.Network.Bridge.Subnet6, "bridge-subnet6", "", "IPv6 subnet of the dual-stack built-in network, the bridge gets the first address")
+	cmd.Flags().BoolVar(&vmmConfig.Network.Bridge.Masquerade, "bridge-masquerade", false, "Masquerade the traffic of the VMs leaving the built-in network")
+	cmd.Flags().StringSliceVar(&vmmConfig.Network.Bridge.Nameservers, "bridge-nameservers", nil, "Nameservers of the VMs on the built-in network, at most two")
+
 	cmd.Flags().BoolVar(&vmmConfig.Jailer.Enable, "jailer-enable", false, "Enable jailer usage")
 	cmd.Flags().StringVar(&vmmConfig.Jailer.ExecFile, "jailer-exec-file", "/usr/local/bin/firecracker", "Path to firecracker binary")
 	cmd.Flags().StringVar(&vmmConfig.Jailer.JailerBinary, "jailer-binary", "/usr/local/bin/jailer", "Path to jailer binary")
@@ -49,4 +63,9 @@ func initVMMConfigFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&vmmConfig.Machine.HtEnabled, "machine-ht-enabled", false, "Enable hyperthreading")
 	cmd.Flags().Int64Var(&vmmConfig.Machine.MemSizeMib, "machine-mem-size", 128, "Memory size of VM in Mib")
 	cmd.Flags().Int64Var(&vmmConfig.Machine.VcpuCount, "machine-vcpu_count", 1, "Number of vCPUs (either 1 or an even number)")
+
+	cmd.Flags().BoolVar(&vmmConfig.Balloon.Enable, "balloon-enable", false, "Attach a memory balloon device to the VMs")
+	cmd.Flags().Int64Var(&vmmConfig.Balloon.AmountMib, "balloon-amount-mib", 0, "Size of the balloon in Mib the VMs boot with")
+	cmd.Flags().BoolVar(&vmmConfig.Balloon.DeflateOnOOM, "balloon-deflate-on-oom", true, "Let the guest deflate the balloon when it runs out of memory")
+	cmd.Flags().DurationVar(&vmmConfig.Balloon.StatsInterval, "balloon-stats-interval", 5*time.Second, "Polling interval of the balloon memory statistics in whole seconds, 0 disables the statistics")
 }
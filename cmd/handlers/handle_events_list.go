@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/events"
+	eventspkg "github.com/combust-labs/firebox/pkg/events"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+)
+
+func NewEventsListEventsHandler(logger *log.Logger, recorder *eventspkg.Recorder) *EventsListEventsHandler {
+	return &EventsListEventsHandler{
+		logger:   logger,
+		recorder: recorder,
+	}
+}
+
+type EventsListEventsHandler struct {
+	logger   *log.Logger
+	recorder *eventspkg.Recorder
+}
+
+func (h *EventsListEventsHandler) Handle(params events.ListEventsParams) middleware.Responder {
+	payload := make([]*models.Event, 0)
+	for _, e := range h.recorder.List() {
+		if params.Service != nil && *params.Service != e.Service {
+			continue
+		}
+		payload = append(payload, &models.Event{
+			Time:    strfmt.DateTime(e.Time),
+			Type:    e.Type,
+			Vmid:    e.VMID,
+			Service: e.Service,
+			Message: e.Message,
+		})
+	}
+	return events.NewListEventsOK().WithPayload(payload)
+}
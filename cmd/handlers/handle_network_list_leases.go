@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"sort"
+
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/network"
+	"github.com/combust-labs/firebox/pkg/log"
+	networkpkg "github.com/combust-labs/firebox/pkg/network"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+)
+
+func NewNetworkListLeasesHandler(logger *log.Logger, bridges map[string]*networkpkg.IPAM) *NetworkListLeasesHandler {
+	return &NetworkListLeasesHandler{
+		logger:  logger,
+		bridges: bridges,
+	}
+}
+
+type NetworkListLeasesHandler struct {
+	logger *log.Logger
+	// bridges are empty when CNI is enabled
+	bridges map[string]*networkpkg.IPAM
+}
+
+func (h *NetworkListLeasesHandler) Handle(params network.ListLeasesParams) middleware.Responder {
+	names := make([]string, 0, len(h.bridges))
+	for name := range h.bridges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	payload := make([]*models.Lease, 0)
+	for _, name := range names {
+		leases, err := h.bridges[name].Leases()
+		if err != nil {
+			err = errors.Wrapf(err, "Leases of bridge %s failed", name)
+			h.logger.Errorf("%v", err)
+			return network.NewListLeasesInternalServerError().WithPayload(&models.StandardError{
+				Code:    500,
+				Message: err.Error(),
+			})
+		}
+		for _, lease := range leases {
+			payload = append(payload, &models.Lease{
+				Bridge:  name,
+				IP:      lease.IP,
+				Vmid:    lease.VMID,
+				Created: strfmt.DateTime(lease.Created),
+			})
+		}
+	}
+	return network.NewListLeasesOK().WithPayload(payload)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"sort"
+
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/profile"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+func NewProfileListProfilesHandler(logger *log.Logger, manager *manager.VMMManager) *ProfileListProfilesHandler {
+	return &ProfileListProfilesHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type ProfileListProfilesHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *ProfileListProfilesHandler) Handle(params profile.ListProfilesParams) middleware.Responder {
+	profiles := h.manager.Profiles()
+	payload := make([]*models.BootProfile, 0, len(profiles))
+	for name, p := range profiles {
+		payload = append(payload, &models.BootProfile{
+			Name:        name,
+			KernelImage: p.KernelImage,
+			Initrd:      p.Initrd,
+			KernelArgs:  p.KernelArgs,
+			Rootfs:      p.RootFS,
+		})
+	}
+	sort.Slice(payload, func(i, j int) bool {
+		return payload[i].Name < payload[j].Name
+	})
+	return profile.NewListProfilesOK().WithPayload(payload)
+}
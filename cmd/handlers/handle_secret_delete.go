@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/secret"
+	"github.com/combust-labs/firebox/pkg/log"
+	secretpkg "github.com/combust-labs/firebox/pkg/secret"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewSecretDeleteSecretHandler(logger *log.Logger, secrets *secretpkg.Store) *SecretDeleteSecretHandler {
+	return &SecretDeleteSecretHandler{
+		logger:  logger,
+		secrets: secrets,
+	}
+}
+
+type SecretDeleteSecretHandler struct {
+	logger  *log.Logger
+	secrets *secretpkg.Store
+}
+
+func (h *SecretDeleteSecretHandler) Handle(params secret.DeleteSecretParams) middleware.Responder {
+	err := h.secrets.Delete(params.Name)
+	if errors.Is(err, secretpkg.ErrNotFound) {
+		return secret.NewDeleteSecretNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Delete failed")
+		h.logger.Errorf("%v", err)
+		return secret.NewDeleteSecretInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	h.logger.Infof("Deleted secret %s", params.Name)
+	return secret.NewDeleteSecretNoContent()
+}
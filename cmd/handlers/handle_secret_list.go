@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/secret"
+	"github.com/combust-labs/firebox/pkg/log"
+	secretpkg "github.com/combust-labs/firebox/pkg/secret"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewSecretListSecretsHandler(logger *log.Logger, secrets *secretpkg.Store) *SecretListSecretsHandler {
+	return &SecretListSecretsHandler{
+		logger:  logger,
+		secrets: secrets,
+	}
+}
+
+type SecretListSecretsHandler struct {
+	logger  *log.Logger
+	secrets *secretpkg.Store
+}
+
+func (h *SecretListSecretsHandler) Handle(params secret.ListSecretsParams) middleware.Responder {
+	secrets, err := h.secrets.List()
+	if err != nil {
+		err = errors.Wrap(err, "List failed")
+		h.logger.Errorf("%v", err)
+		return secret.NewListSecretsInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	payload := make([]*models.Secret, 0, len(secrets))
+	for i := range secrets {
+		payload = append(payload, toSecret(&secrets[i]))
+	}
+	return secret.NewListSecretsOK().WithPayload(payload)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/secret"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	secretpkg "github.com/combust-labs/firebox/pkg/secret"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+)
+
+func NewSecretPutSecretHandler(logger *log.Logger, manager *manager.VMMManager) *SecretPutSecretHandler {
+	return &SecretPutSecretHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type SecretPutSecretHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *SecretPutSecretHandler) Handle(params secret.PutSecretParams) middleware.Responder {
+	s, err := h.manager.PutSecret(params.Name, *params.Data.Value)
+	if errors.Is(err, secretpkg.ErrInvalidName) {
+		return secret.NewPutSecretBadRequest().WithPayload(&models.StandardError{
+			Code:    400,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Put failed")
+		h.logger.Errorf("%v", err)
+		return secret.NewPutSecretInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return secret.NewPutSecretOK().WithPayload(toSecret(s))
+}
+
+func toSecret(s *secretpkg.Secret) *models.Secret {
+	return &models.Secret{
+		Name:    s.Name,
+		Version: s.Version,
+		Updated: strfmt.DateTime(s.Updated),
+	}
+}
@@ -5,10 +5,16 @@ import (
 	"github.com/combust-labs/firebox/api/server/restapi/service"
 	"github.com/combust-labs/firebox/pkg/actors/manager"
 	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/combust-labs/firebox/pkg/tracing"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = tracing.Tracer("github.com/combust-labs/firebox/cmd/handlers")
+
 func NewServiceInvokeHandler(logger *log.Logger, manager *manager.VMMManager) service.InvokeHandler {
 	return &serviceInvokeHandler{
 		logger:  logger,
@@ -22,7 +28,11 @@ type serviceInvokeHandler struct {
 }
 
 func (h *serviceInvokeHandler) Handle(params service.InvokeParams) middleware.Responder {
-	resp, err := h.manager.InvokeHTTP(params.Data)
+	ctx := tracing.Extract(params.HTTPRequest.Context(), propagation.HeaderCarrier(params.HTTPRequest.Header))
+	ctx, span := tracer.Start(ctx, "invoke", trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attribute.String("firebox.service", *params.Service)))
+
+	resp, err := h.manager.InvokeHTTP(ctx, *params.Service, params.Data)
+	tracing.End(span, err)
 	if err != nil {
 		// it could be also 500
 		return service.NewInvokeServiceUnavailable().WithPayload(&models.StandardError{
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/snapshot"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	snapshotpkg "github.com/combust-labs/firebox/pkg/snapshot"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+)
+
+func NewSnapshotCreateSnapshotHandler(logger *log.Logger, manager *manager.VMMManager) *SnapshotCreateSnapshotHandler {
+	return &SnapshotCreateSnapshotHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type SnapshotCreateSnapshotHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *SnapshotCreateSnapshotHandler) Handle(params snapshot.CreateSnapshotParams) middleware.Responder {
+	s, err := h.manager.Snapshot(params.ID, swag.StringValue(params.Spec.Name))
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return snapshot.NewCreateSnapshotNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, snapshotpkg.ErrInvalidName) {
+		return snapshot.NewCreateSnapshotBadRequest().WithPayload(&models.StandardError{
+			Code:    400,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, snapshotpkg.ErrExists) {
+		return snapshot.NewCreateSnapshotConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Snapshot failed")
+		h.logger.Errorf("%v", err)
+		return snapshot.NewCreateSnapshotInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	h.logger.Infof("Created snapshot %s of vmid %s", s.Name, s.VMID)
+	return snapshot.NewCreateSnapshotCreated().WithPayload(toSnapshot(s))
+}
+
+func toSnapshot(s *snapshotpkg.Snapshot) *models.Snapshot {
+	return &models.Snapshot{
+		Name:    s.Name,
+		Vmid:    s.VMID,
+		Service: s.Service,
+		IP:      s.IP,
+		Created: strfmt.DateTime(s.Created),
+	}
+}
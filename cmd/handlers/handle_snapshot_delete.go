@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/snapshot"
+	"github.com/combust-labs/firebox/pkg/log"
+	snapshotpkg "github.com/combust-labs/firebox/pkg/snapshot"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewSnapshotDeleteSnapshotHandler(logger *log.Logger, snapshots *snapshotpkg.Store) *SnapshotDeleteSnapshotHandler {
+	return &SnapshotDeleteSnapshotHandler{
+		logger:    logger,
+		snapshots: snapshots,
+	}
+}
+
+type SnapshotDeleteSnapshotHandler struct {
+	logger    *log.Logger
+	snapshots *snapshotpkg.Store
+}
+
+func (h *SnapshotDeleteSnapshotHandler) Handle(params snapshot.DeleteSnapshotParams) middleware.Responder {
+	err := h.snapshots.Delete(params.Name)
+	if errors.Is(err, snapshotpkg.ErrNotFound) {
+		return snapshot.NewDeleteSnapshotNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Delete failed")
+		h.logger.Errorf("%v", err)
+		return snapshot.NewDeleteSnapshotInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	h.logger.Infof("Deleted snapshot %s", params.Name)
+	return snapshot.NewDeleteSnapshotNoContent()
+}
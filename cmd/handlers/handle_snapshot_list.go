@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/snapshot"
+	"github.com/combust-labs/firebox/pkg/log"
+	snapshotpkg "github.com/combust-labs/firebox/pkg/snapshot"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewSnapshotListSnapshotsHandler(logger *log.Logger, snapshots *snapshotpkg.Store) *SnapshotListSnapshotsHandler {
+	return &SnapshotListSnapshotsHandler{
+		logger:    logger,
+		snapshots: snapshots,
+	}
+}
+
+type SnapshotListSnapshotsHandler struct {
+	logger    *log.Logger
+	snapshots *snapshotpkg.Store
+}
+
+func (h *SnapshotListSnapshotsHandler) Handle(params snapshot.ListSnapshotsParams) middleware.Responder {
+	snapshots, err := h.snapshots.List()
+	if err != nil {
+		err = errors.Wrap(err, "List failed")
+		h.logger.Errorf("%v", err)
+		return snapshot.NewListSnapshotsInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	payload := make([]*models.Snapshot, 0, len(snapshots))
+	for i := range snapshots {
+		payload = append(payload, toSnapshot(&snapshots[i]))
+	}
+	return snapshot.NewListSnapshotsOK().WithPayload(payload)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/console"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+func NewVMAttachVMConsoleHandler(logger *log.Logger, manager *manager.VMMManager) *VMAttachVMConsoleHandler {
+	return &VMAttachVMConsoleHandler{
+		logger:   logger,
+		manager:  manager,
+		upgrader: websocket.Upgrader{},
+	}
+}
+
+type VMAttachVMConsoleHandler struct {
+	logger   *log.Logger
+	manager  *manager.VMMManager
+	upgrader websocket.Upgrader
+}
+
+func (h *VMAttachVMConsoleHandler) Handle(params vm.AttachVMConsoleParams) middleware.Responder {
+	c, err := h.manager.Console(params.ID)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewAttachVMConsoleNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if err == nil && c == nil {
+		err = errors.New("console is not available")
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Console failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewAttachVMConsoleInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		conn, err := h.upgrader.Upgrade(rw, params.HTTPRequest, nil)
+		if err != nil {
+			// the upgrader has already replied with an HTTP error
+			h.logger.Warnf("console upgrade for vmid %s failed: %v", params.ID, err)
+			return
+		}
+		h.logger.Infof("console of vmid %s attached by %s", params.ID, params.HTTPRequest.RemoteAddr)
+		h.attach(conn, c)
+		h.logger.Infof("console of vmid %s detached by %s", params.ID, params.HTTPRequest.RemoteAddr)
+	})
+}
+
+func (h *VMAttachVMConsoleHandler) attach(conn *websocket.Conn, c *console.Console) {
+	defer conn.Close()
+
+	output, cancel := c.Subscribe()
+	defer cancel()
+
+	inputDone := make(chan struct{})
+	go func() {
+		defer close(inputDone)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if _, err := c.Input(data); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-inputDone:
+			return
+		case data, ok := <-output:
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "VM stopped"))
+				return
+			}
+			if err := conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+				return
+			}
+		}
+	}
+}
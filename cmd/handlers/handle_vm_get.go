@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMGetVMHandler(logger *log.Logger, manager *manager.VMMManager) *VMGetVMHandler {
+	return &VMGetVMHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMGetVMHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMGetVMHandler) Handle(params vm.GetVMParams) middleware.Responder {
+	machine, err := h.manager.GetVM(params.ID)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewGetVMNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	return vm.NewGetVMOK().WithPayload(toVM(machine))
+}
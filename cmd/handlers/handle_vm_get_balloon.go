@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	vmmpkg "github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMGetVMBalloonHandler(logger *log.Logger, manager *manager.VMMManager) *VMGetVMBalloonHandler {
+	return &VMGetVMBalloonHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMGetVMBalloonHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMGetVMBalloonHandler) Handle(params vm.GetVMBalloonParams) middleware.Responder {
+	stats, err := h.manager.Balloon(params.ID)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewGetVMBalloonNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, vmmpkg.ErrNoBalloon) {
+		return vm.NewGetVMBalloonConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Balloon failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewGetVMBalloonInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return vm.NewGetVMBalloonOK().WithPayload(&models.Balloon{
+		TargetMib:       stats.TargetMib,
+		ActualMib:       stats.ActualMib,
+		TotalMemory:     stats.TotalMemory,
+		FreeMemory:      stats.FreeMemory,
+		AvailableMemory: stats.AvailableMemory,
+		DiskCaches:      stats.DiskCaches,
+		SwapIn:          stats.SwapIn,
+		SwapOut:         stats.SwapOut,
+		MajorFaults:     stats.MajorFaults,
+		MinorFaults:     stats.MinorFaults,
+	})
+}
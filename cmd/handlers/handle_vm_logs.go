@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMGetVMLogsHandler(logger *log.Logger, manager *manager.VMMManager) *VMGetVMLogsHandler {
+	return &VMGetVMLogsHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMGetVMLogsHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMGetVMLogsHandler) Handle(params vm.GetVMLogsParams) middleware.Responder {
+	console, err := h.manager.Console(params.ID)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewGetVMLogsNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if err == nil && console == nil {
+		err = errors.New("console is not available")
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Console failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewGetVMLogsInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	tail := int(*params.Tail)
+	if !*params.Follow {
+		return vm.NewGetVMLogsOK().WithPayload(string(console.Tail(tail)))
+	}
+
+	ctx := params.HTTPRequest.Context()
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		data, updates, cancel := console.Follow(tail)
+		defer cancel()
+
+		// following lasts until the client leaves, it is not limited by the write timeout of the server
+		rc := http.NewResponseController(rw)
+		_ = rc.SetWriteDeadline(time.Time{})
+		rw.Header().Set("Content-Type", runtime.TextMime)
+		rw.WriteHeader(http.StatusOK)
+		for {
+			if _, err := rw.Write(data); err != nil {
+				return
+			}
+			_ = rc.Flush()
+			select {
+			case <-ctx.Done():
+				return
+			case chunk, ok := <-updates:
+				if !ok {
+					return
+				}
+				data = chunk
+			}
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMGetVMMetricsHandler(logger *log.Logger, manager *manager.VMMManager) *VMGetVMMetricsHandler {
+	return &VMGetVMMetricsHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMGetVMMetricsHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMGetVMMetricsHandler) Handle(params vm.GetVMMetricsParams) middleware.Responder {
+	machineMetrics, err := h.manager.MachineMetrics(params.ID)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewGetVMMetricsNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "MachineMetrics failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewGetVMMetricsInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return vm.NewGetVMMetricsOK().WithPayload(models.VMMetrics(machineMetrics))
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	vmmactor "github.com/combust-labs/firebox/pkg/actors/vmm"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMPauseVMHandler(logger *log.Logger, manager *manager.VMMManager) *VMPauseVMHandler {
+	return &VMPauseVMHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMPauseVMHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMPauseVMHandler) Handle(params vm.PauseVMParams) middleware.Responder {
+	err := h.manager.Pause(params.ID)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewPauseVMNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, vmmactor.ErrPaused) || errors.Is(err, vmmactor.ErrNotPaused) {
+		return vm.NewPauseVMConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Pause failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewPauseVMInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return vm.NewPauseVMNoContent()
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	vmmpkg "github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMPutVMMetadataHandler(logger *log.Logger, manager *manager.VMMManager) *VMPutVMMetadataHandler {
+	return &VMPutVMMetadataHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMPutVMMetadataHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMPutVMMetadataHandler) Handle(params vm.PutVMMetadataParams) middleware.Responder {
+	err := h.manager.SetMetadata(params.ID, params.Data)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewPutVMMetadataNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, vmmpkg.ErrMMDSDisabled) {
+		return vm.NewPutVMMetadataConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Metadata update failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewPutVMMetadataInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return vm.NewPutVMMetadataNoContent()
+}
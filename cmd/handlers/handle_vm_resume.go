@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	vmmactor "github.com/combust-labs/firebox/pkg/actors/vmm"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMResumeVMHandler(logger *log.Logger, manager *manager.VMMManager) *VMResumeVMHandler {
+	return &VMResumeVMHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMResumeVMHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMResumeVMHandler) Handle(params vm.ResumeVMParams) middleware.Responder {
+	err := h.manager.Resume(params.ID)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewResumeVMNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, vmmactor.ErrPaused) || errors.Is(err, vmmactor.ErrNotPaused) {
+		return vm.NewResumeVMConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Resume failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewResumeVMInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return vm.NewResumeVMNoContent()
+}
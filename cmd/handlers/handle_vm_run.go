@@ -3,10 +3,19 @@ package handlers
 import (
 	"github.com/combust-labs/firebox/api/models"
 	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/config"
 	"github.com/combust-labs/firebox/pkg/actors/manager"
 	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/combust-labs/firebox/pkg/secret"
+	"github.com/combust-labs/firebox/pkg/snapshot"
+	"github.com/combust-labs/firebox/pkg/tracing"
+	"github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/combust-labs/firebox/pkg/volume"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 func NewVMPostVMRunHandler(logger *log.Logger, manager *manager.VMMManager) *VMPostVMRunHandler {
@@ -21,8 +30,50 @@ type VMPostVMRunHandler struct {
 	manager *manager.VMMManager
 }
 
-func (h *VMPostVMRunHandler) Handle(_ vm.PostVMRunParams) middleware.Responder {
-	machine, err := h.manager.StartVMM()
+func (h *VMPostVMRunHandler) Handle(params vm.PostVMRunParams) middleware.Responder {
+	var spec manager.VMSpec
+	if params.Spec != nil {
+		spec.Service = params.Spec.Service
+		spec.Snapshot = params.Spec.Snapshot
+		spec.Profile = params.Spec.Profile
+		spec.Labels = params.Spec.Labels
+		spec.Metadata = params.Spec.Metadata
+		if spec.Snapshot != "" && len(params.Spec.Drives) > 0 {
+			return vm.NewPostVMRunBadRequest().WithPayload(&models.StandardError{
+				Code:    400,
+				Message: "drives are taken from the snapshot",
+			})
+		}
+		if spec.Snapshot != "" && spec.Profile != "" {
+			return vm.NewPostVMRunBadRequest().WithPayload(&models.StandardError{
+				Code:    400,
+				Message: "boot profile can not be used with a snapshot",
+			})
+		}
+		drives, err := toDriveConfigs(params.Spec.Drives)
+		if err != nil {
+			return vm.NewPostVMRunBadRequest().WithPayload(&models.StandardError{
+				Code:    400,
+				Message: err.Error(),
+			})
+		}
+		spec.Drives = drives
+		for _, iface := range params.Spec.Interfaces {
+			spec.Interfaces = append(spec.Interfaces, toInterfaceConfig(iface))
+		}
+	}
+	ctx := tracing.Extract(params.HTTPRequest.Context(), propagation.HeaderCarrier(params.HTTPRequest.Header))
+	machine, err := h.manager.StartVMM(ctx, spec)
+	if errors.Is(err, volume.ErrNotFound) || errors.Is(err, volume.ErrAttached) || errors.Is(err, snapshot.ErrNotFound) ||
+		errors.Is(err, vmm.ErrMMDSDisabled) || errors.Is(err, secret.ErrNotFound) || errors.Is(err, manager.ErrProfileNotFound) ||
+		errors.Is(err, manager.ErrNetworkNotFound) || errors.Is(err, manager.ErrInterfacesWithSnapshot) ||
+		errors.Is(err, manager.ErrInvalidInterface) || errors.Is(err, manager.ErrInvalidDrive) ||
+		errors.Is(err, volume.ErrInvalidSize) {
+		return vm.NewPostVMRunBadRequest().WithPayload(&models.StandardError{
+			Code:    400,
+			Message: err.Error(),
+		})
+	}
 	if err != nil {
 		err = errors.Wrap(err, "StartVMM failed")
 		h.logger.Errorf("%v", err)
@@ -31,8 +82,105 @@ func (h *VMPostVMRunHandler) Handle(_ vm.PostVMRunParams) middleware.Responder {
 			Message: err.Error(),
 		})
 	}
-	return vm.NewPostVMRunOK().WithPayload(&models.VM{
-		ID: machine.ID,
-		IP: machine.IP.String(),
-	})
+	return vm.NewPostVMRunOK().WithPayload(toVM(machine))
+}
+
+func toVM(machine *manager.VM) *models.VM {
+	result := &models.VM{
+		ID:         machine.ID,
+		IP:         machine.IP.String(),
+		Interfaces: make([]*models.NetworkInterface, 0, len(machine.Interfaces)),
+		Service:    machine.Service,
+		Labels:     machine.Labels,
+	}
+	for _, iface := range machine.Interfaces {
+		nic := &models.NetworkInterface{
+			Network:     iface.Network,
+			IfName:      iface.IfName,
+			Mac:         iface.MacAddress,
+			HostDevName: iface.HostDevName,
+			AllowMMDS:   iface.AllowMMDS,
+		}
+		if iface.IP.IP != nil {
+			nic.IP = iface.IP.String()
+		}
+		if iface.Gateway != nil {
+			nic.Gateway = iface.Gateway.String()
+		}
+		for _, a := range iface.Addresses {
+			address := &models.NetworkAddress{IP: a.IP.String()}
+			if a.Gateway != nil {
+				address.Gateway = a.Gateway.String()
+			}
+			nic.Addresses = append(nic.Addresses, address)
+		}
+		result.Interfaces = append(result.Interfaces, nic)
+	}
+	if len(machine.Interfaces) > 0 {
+		for _, ip := range machine.Interfaces[0].IPs() {
+			result.Ips = append(result.Ips, ip.String())
+		}
+	}
+	for _, c := range machine.Conditions {
+		result.Conditions = append(result.Conditions, &models.Condition{
+			Type:               swag.String(c.Type),
+			Status:             swag.Bool(c.Status),
+			Message:            c.Message,
+			LastTransitionTime: strfmt.DateTime(c.LastTransition),
+		})
+	}
+	return result
+}
+
+func toInterfaceConfig(iface *models.NetworkInterfaceRequest) config.InterfaceConfig {
+	result := config.InterfaceConfig{
+		Network:   *iface.Network,
+		AllowMMDS: iface.AllowMMDS,
+		IPs:       iface.Ips,
+		MAC:       iface.Mac,
+	}
+	for _, pm := range iface.PortMappings {
+		result.PortMappings = append(result.PortMappings, config.PortMapping{
+			HostPort:  int(*pm.HostPort),
+			GuestPort: int(*pm.GuestPort),
+			Protocol:  pm.Protocol,
+			HostIP:    pm.HostIP,
+		})
+	}
+	if b := iface.Bandwidth; b != nil {
+		result.Bandwidth = &config.BandwidthConfig{
+			IngressRate:  swag.Int64Value(b.IngressRate),
+			IngressBurst: swag.Int64Value(b.IngressBurst),
+			EgressRate:   swag.Int64Value(b.EgressRate),
+			EgressBurst:  swag.Int64Value(b.EgressBurst),
+		}
+	}
+	return result
+}
+
+func toDriveConfigs(drives []*models.Drive) ([]config.DriveConfig, error) {
+	result := make([]config.DriveConfig, 0, len(drives))
+	for i, drive := range drives {
+		var set int
+		for _, ok := range []bool{drive.Path != "", drive.SizeMib != 0, drive.Volume != ""} {
+			if ok {
+				set++
+			}
+		}
+		if set != 1 {
+			return nil, errors.Errorf("drive %d: exactly one of path, sizeMib and volume must be set", i)
+		}
+		switch {
+		case drive.Path != "":
+			// data disks are shared with the host and other machines
+			result = append(result, config.DriveConfig{Path: drive.Path, ReadOnly: true})
+		case drive.SizeMib > 0:
+			result = append(result, config.DriveConfig{SizeMib: drive.SizeMib})
+		case drive.Volume != "":
+			result = append(result, config.DriveConfig{Volume: drive.Volume, ReadOnly: drive.ReadOnly})
+		default:
+			return nil, errors.Errorf("drive %d: invalid size %d MiB", i, drive.SizeMib)
+		}
+	}
+	return result, nil
 }
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/vm"
+	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/log"
+	vmmpkg "github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVMUpdateVMBalloonHandler(logger *log.Logger, manager *manager.VMMManager) *VMUpdateVMBalloonHandler {
+	return &VMUpdateVMBalloonHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type VMUpdateVMBalloonHandler struct {
+	logger  *log.Logger
+	manager *manager.VMMManager
+}
+
+func (h *VMUpdateVMBalloonHandler) Handle(params vm.UpdateVMBalloonParams) middleware.Responder {
+	err := h.manager.SetBalloon(params.ID, *params.Data.AmountMib)
+	if errors.Is(err, manager.ErrVMNotFound) {
+		return vm.NewUpdateVMBalloonNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, vmmpkg.ErrBalloonSize) {
+		return vm.NewUpdateVMBalloonBadRequest().WithPayload(&models.StandardError{
+			Code:    400,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, vmmpkg.ErrNoBalloon) {
+		return vm.NewUpdateVMBalloonConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Balloon update failed")
+		h.logger.Errorf("%v", err)
+		return vm.NewUpdateVMBalloonInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	return vm.NewUpdateVMBalloonNoContent()
+}
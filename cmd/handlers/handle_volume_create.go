@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/volume"
+	"github.com/combust-labs/firebox/pkg/log"
+	volumepkg "github.com/combust-labs/firebox/pkg/volume"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+)
+
+func NewVolumeCreateVolumeHandler(logger *log.Logger, volumes *volumepkg.Store) *VolumeCreateVolumeHandler {
+	return &VolumeCreateVolumeHandler{
+		logger:  logger,
+		volumes: volumes,
+	}
+}
+
+type VolumeCreateVolumeHandler struct {
+	logger  *log.Logger
+	volumes *volumepkg.Store
+}
+
+func (h *VolumeCreateVolumeHandler) Handle(params volume.CreateVolumeParams) middleware.Responder {
+	v, err := h.volumes.Create(swag.StringValue(params.Spec.Name), swag.Int64Value(params.Spec.SizeMib))
+	if errors.Is(err, volumepkg.ErrInvalidName) || errors.Is(err, volumepkg.ErrInvalidSize) {
+		return volume.NewCreateVolumeBadRequest().WithPayload(&models.StandardError{
+			Code:    400,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, volumepkg.ErrExists) {
+		return volume.NewCreateVolumeConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Create failed")
+		h.logger.Errorf("%v", err)
+		return volume.NewCreateVolumeInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	h.logger.Infof("Created volume %s of %d MiB", v.Name, v.SizeMib)
+	return volume.NewCreateVolumeCreated().WithPayload(toVolume(v))
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/volume"
+	"github.com/combust-labs/firebox/pkg/log"
+	volumepkg "github.com/combust-labs/firebox/pkg/volume"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVolumeDeleteVolumeHandler(logger *log.Logger, volumes *volumepkg.Store) *VolumeDeleteVolumeHandler {
+	return &VolumeDeleteVolumeHandler{
+		logger:  logger,
+		volumes: volumes,
+	}
+}
+
+type VolumeDeleteVolumeHandler struct {
+	logger  *log.Logger
+	volumes *volumepkg.Store
+}
+
+func (h *VolumeDeleteVolumeHandler) Handle(params volume.DeleteVolumeParams) middleware.Responder {
+	err := h.volumes.Delete(params.Name)
+	if errors.Is(err, volumepkg.ErrNotFound) {
+		return volume.NewDeleteVolumeNotFound().WithPayload(&models.StandardError{
+			Code:    404,
+			Message: err.Error(),
+		})
+	}
+	if errors.Is(err, volumepkg.ErrAttached) {
+		return volume.NewDeleteVolumeConflict().WithPayload(&models.StandardError{
+			Code:    409,
+			Message: err.Error(),
+		})
+	}
+	if err != nil {
+		err = errors.Wrap(err, "Delete failed")
+		h.logger.Errorf("%v", err)
+		return volume.NewDeleteVolumeInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	h.logger.Infof("Deleted volume %s", params.Name)
+	return volume.NewDeleteVolumeNoContent()
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/combust-labs/firebox/api/models"
+	"github.com/combust-labs/firebox/api/server/restapi/volume"
+	"github.com/combust-labs/firebox/pkg/log"
+	volumepkg "github.com/combust-labs/firebox/pkg/volume"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+)
+
+func NewVolumeListVolumesHandler(logger *log.Logger, volumes *volumepkg.Store) *VolumeListVolumesHandler {
+	return &VolumeListVolumesHandler{
+		logger:  logger,
+		volumes: volumes,
+	}
+}
+
+type VolumeListVolumesHandler struct {
+	logger  *log.Logger
+	volumes *volumepkg.Store
+}
+
+func (h *VolumeListVolumesHandler) Handle(params volume.ListVolumesParams) middleware.Responder {
+	volumes, err := h.volumes.List()
+	if err != nil {
+		err = errors.Wrap(err, "List failed")
+		h.logger.Errorf("%v", err)
+		return volume.NewListVolumesInternalServerError().WithPayload(&models.StandardError{
+			Code:    500,
+			Message: err.Error(),
+		})
+	}
+	payload := make([]*models.Volume, 0, len(volumes))
+	for i := range volumes {
+		payload = append(payload, toVolume(&volumes[i]))
+	}
+	return volume.NewListVolumesOK().WithPayload(payload)
+}
+
+func toVolume(v *volumepkg.Volume) *models.Volume {
+	return &models.Volume{
+		Name:       v.Name,
+		SizeMib:    v.SizeMib,
+		AttachedTo: v.AttachedTo,
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/combust-labs/firebox/pkg/network"
+	"github.com/spf13/cobra"
+)
+
+type NetworkInitConfig struct {
+	Bridge string
+	Subnet string
+	// Subnet6 is the IPv6 subnet of a dual-stack network
+	Subnet6 string
+	// IPMasq masquerades the traffic of the VMs leaving the subnet
+	IPMasq bool
+	// Force overwrites an existing network configuration list
+	Force bool
+}
+
+var (
+	networkInitConfig = new(NetworkInitConfig)
+)
+
+// networkCmd represents the network command
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Manage the network of the VMs",
+}
+
+// networkInitCmd represents the network init command
+var networkInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate the CNI network configuration list of the VMs and validate the CNI plugins",
+	Run: func(cmd *cobra.Command, args []string) {
+		networkInitRun()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(networkCmd)
+	networkCmd.AddCommand(networkInitCmd)
+
+	networkInitCmd.Flags().StringVar(&vmmConfig.Network.CNI.BinDir, "cni-bin-dir", "/opt/cni/bin", "CNI plugins binaries directory")
+	networkInitCmd.Flags().StringVar(&vmmConfig.Network.CNI.ConfDir, "cni-conf-dir", "/etc/cni/conf.d", "CNI configuration directory")
+	networkInitCmd.Flags().StringVar(&vmmConfig.Network.CNI.NetworkName, "cni-network-name", "firebox", "Name in the Network Configuration List")
+	networkInitCmd.Flags().StringVar(&networkInitConfig.Bridge, "cni-bridge-name", "fireboxbr0", "Bridge the bridge plugin connects the VMs to")
+	networkInitCmd.Flags().StringVar(&networkInitConfig.Subnet, "cni-subnet", "192.168.128.0/24", "Subnet the host-local plugin assigns the guest IPs from")
+	networkInitCmd.Flags().StringVar(&networkInitConfig.Subnet6, "cni-subnet6", "", "IPv6 subnet the host-local plugin assigns the guest IPs of a dual-stack network from")
+	networkInitCmd.Flags().BoolVar(&networkInitConfig.IPMasq, "cni-ip-masq", false, "Masquerade the traffic of the VMs leaving the subnet, without it the VMs reach the hosts of the subnet only")
+	networkInitCmd.Flags().BoolVar(&networkInitConfig.Force, "force", false, "Overwrite an existing network configuration list")
+}
+
+func networkInitRun() {
+	logger := newLogger()
+
+	cni := vmmConfig.Network.CNI
+	data, err := network.CNIConfList(cni.NetworkName, networkInitConfig.Bridge, networkInitConfig.Subnet, networkInitConfig.Subnet6, networkInitConfig.IPMasq)
+	if err != nil {
+		logger.Fatalf("%v", err)
+	}
+	file := network.CNIConfFile(cni.ConfDir, cni.NetworkName)
+	if _, err := os.Stat(file); err == nil && !networkInitConfig.Force {
+		logger.Fatalf("%s exists already, use --force to overwrite it", file)
+	}
+	if err := os.MkdirAll(cni.ConfDir, 0755); err != nil {
+		logger.Fatalf("creating %s failed: %v", cni.ConfDir, err)
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		logger.Fatalf("writing %s failed: %v", file, err)
+	}
+	logger.Infof("Generated CNI network '%s' in %s", cni.NetworkName, file)
+	if err := network.ValidateCNI(cni, []string{cni.NetworkName}); err != nil {
+		logger.Fatalf("%v", err)
+	}
+	logger.Infof("CNI plugins found in %s", cni.BinDir)
+}
@@ -2,18 +2,27 @@ package cmd
 
 import (
 	"context"
+	"path/filepath"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/combust-labs/firebox/api/server"
 	"github.com/combust-labs/firebox/api/server/restapi"
 	"github.com/combust-labs/firebox/cmd/handlers"
+	"github.com/combust-labs/firebox/config"
 	"github.com/combust-labs/firebox/pkg/actors/manager"
+	"github.com/combust-labs/firebox/pkg/dns"
+	"github.com/combust-labs/firebox/pkg/events"
 	"github.com/combust-labs/firebox/pkg/flags"
 	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/combust-labs/firebox/pkg/network"
 	"github.com/combust-labs/firebox/pkg/prober"
 	localprober "github.com/combust-labs/firebox/pkg/prober/local"
+	"github.com/combust-labs/firebox/pkg/secret"
+	"github.com/combust-labs/firebox/pkg/snapshot"
+	"github.com/combust-labs/firebox/pkg/tracing"
 	"github.com/combust-labs/firebox/pkg/utils"
+	"github.com/combust-labs/firebox/pkg/volume"
 	"github.com/go-openapi/loads"
 	"github.com/pkg/errors"
 
@@ -22,6 +31,16 @@ import (
 
 type ServerConfig struct {
 	server.Server
+	Tracing config.TracingConfig
+	// EventsSize is the number of the latest events kept in memory
+	EventsSize int
+	// SecretsKeyFile holds the key the secrets are encrypted with
+	SecretsKeyFile string
+	// CNIValidate checks the CNI networks and plugins on start
+	CNIValidate bool
+	// NetworkPolicyAllowAll allows all traffic of the VMs of the services without a network policy,
+	// by default the traffic of these VMs is dropped
+	NetworkPolicyAllowAll bool
 }
 
 var (
@@ -68,6 +87,36 @@ func init() {
 	serverFlags.DurationVar(&serverConfig.TLSReadTimeout, "server-tls-read-timeout", 0, "maximum duration before timing out read of the request")
 	serverFlags.DurationVar(&serverConfig.TLSWriteTimeout, "server-tls-write-timeout", 0, "maximum duration before timing out write of the response")
 
+	serverFlags.StringVar(&serverConfig.Tracing.Exporter, "tracing-exporter", config.TracingExporterNone, "Tracing exporter. One of: [none, otlp, stdout]")
+	serverFlags.StringVar(&serverConfig.Tracing.OTLPEndpoint, "tracing-otlp-endpoint", "localhost:4317", "OTLP gRPC endpoint the traces are exported to")
+	serverFlags.BoolVar(&serverConfig.Tracing.OTLPInsecure, "tracing-otlp-insecure", true, "Disable TLS for the OTLP exporter")
+	serverFlags.StringVar(&serverConfig.Tracing.ServiceName, "tracing-service-name", "firebox", "Service name reported in the traces")
+	serverFlags.Float64Var(&serverConfig.Tracing.SampleRatio, "tracing-sample-ratio", 1, "Ratio of the traces started by firebox which are sampled")
+
+	serverFlags.DurationVar(&vmmConfig.Balloon.ReclaimIdleTimeout, "balloon-reclaim-idle-timeout", 0, "Inflate the balloon of the VMs without invocation for the given time, 0 disables the reclaim")
+	serverFlags.Int64Var(&vmmConfig.Balloon.ReclaimMib, "balloon-reclaim-mib", 64, "Size of the balloon in Mib of the idle VMs")
+	serverFlags.DurationVar(&vmmConfig.Balloon.ReclaimCheckInterval, "balloon-reclaim-check-interval", 10*time.Second, "Interval of the checks for VMs to reclaim memory from")
+
+	serverFlags.StringVar(&vmmConfig.DataDir, "data-dir", "", "Directory of the data disks the VMs may attach by path, defaults to data in the work dir")
+	serverFlags.Int64Var(&vmmConfig.MaxDiskSizeMib, "max-disk-size-mib", 10240, "Maximum size in Mib of the volumes and scratch disks")
+
+	serverFlags.StringVar(&serverConfig.SecretsKeyFile, "secrets-key-file", "", "File with the 32 bytes key the secrets are encrypted with, generated when it does not exist, defaults to secrets.key in the work dir")
+
+	serverFlags.BoolVar(&serverConfig.CNIValidate, "cni-validate", true, "Check on start that the CNI networks of the VMs are configured and their plugins exist in --cni-bin-dir")
+
+	serverFlags.IntVar(&serverConfig.EventsSize, "events-size", 1000, "Number of the latest VM events kept in memory")
+
+	serverFlags.DurationVar(&vmmConfig.Hibernation.IdleTimeout, "hibernate-idle-timeout", 0, "Hibernate the VMs without invocation for the given time to disk, 0 disables hibernation")
+	serverFlags.DurationVar(&vmmConfig.Hibernation.CheckInterval, "hibernate-check-interval", 10*time.Second, "Interval of the checks for idle VMs")
+
+	serverFlags.DurationVar(&vmmConfig.NetworkCheck.Interval, "network-check-interval", 0, "Interval of the checks of the network attachments of the VMs, 0 disables the checks")
+	serverFlags.StringVar(&vmmConfig.NetworkCheck.Repair, "network-repair", config.NetworkRepairNone, "Repair of the VMs failing the network check. One of: [none, reattach, replace]")
+	serverFlags.BoolVar(&vmmConfig.DNS.Enable, "dns-enable", false, "Serve DNS on the gateways of the VMs and make it their nameserver, it resolves <vmid>.vm.<domain>, <service>.svc.<domain> and <value>.<key>.label.<domain>")
+	serverFlags.StringVar(&vmmConfig.DNS.Domain, "dns-domain", dns.DefaultDomain, "Domain of the names of the VMs")
+	serverFlags.DurationVar(&vmmConfig.DNS.TTL, "dns-ttl", 5*time.Second, "TTL of the DNS answers for the VMs")
+	serverFlags.StringSliceVar(&vmmConfig.DNS.Upstream, "dns-upstream", nil, "Nameservers the other DNS queries are forwarded to, defaults to the nameservers in /etc/resolv.conf")
+	serverFlags.BoolVar(&serverConfig.NetworkPolicyAllowAll, "network-policy-allow-all", false, "Allow all traffic of the VMs of the services without a network policy, by default they reach neither the host, nor the other VMs, nor the internet")
+
 	initVMMConfigFlags(serverCmd)
 }
 
@@ -137,6 +186,51 @@ func runServer() {
 	ctx := context.Background()
 
 	logger := newLogger()
+	shutdownTracing, err := tracing.Init(ctx, serverConfig.Tracing)
+	if err != nil {
+		logger.WithError(err).Fatalf("tracing initialization failed")
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.WithError(err).Warn("tracing shutdown failed")
+		}
+	}()
+
+	if err := loadServicesConfig(); err != nil {
+		logger.WithError(err).Fatalf("loading services configuration failed")
+	}
+	logger.Infof("Services configured: %v", len(vmmConfig.Services))
+	if err := loadProfilesConfig(); err != nil {
+		logger.WithError(err).Fatalf("loading boot profiles failed")
+	}
+	logger.Infof("Boot profiles configured: %v", len(vmmConfig.Profiles))
+	if vmmConfig.Network.CNI.Enable && serverConfig.CNIValidate {
+		if err := network.ValidateCNI(vmmConfig.Network.CNI, cniNetworks()); err != nil {
+			logger.WithError(err).Fatalf("CNI validation failed")
+		}
+	}
+
+	if vmmConfig.DNS.Enable {
+		if err := dns.ValidateConfig(vmmConfig.DNS); err != nil {
+			logger.WithError(err).Fatalf("DNS configuration failed")
+		}
+	}
+
+	if !serverConfig.NetworkPolicyAllowAll {
+		// the services without a network policy get the policy allowing no connections
+		vmmConfig.NetworkPolicy = &config.NetworkPolicyConfig{}
+	}
+
+	if vmmConfig.DataDir == "" {
+		vmmConfig.DataDir = filepath.Join(vmmConfig.WorkDir, "data")
+	}
+
+	if vmmConfig.NetNS != "" {
+		// a namespace shared by all VMs mixes up their routes and firewall rules
+		logger.Warnf("--net-ns is ignored by the server, each VM gets its own network namespace in %s", vmmConfig.NetNSDir)
+		vmmConfig.NetNS = ""
+	}
+
 	srv, err := NewServer(ctx, logger)
 	if err != nil {
 		logger.WithError(err).Fatalf("preparing server failed")
@@ -164,12 +258,67 @@ func (s *Server) instantiateAPI() (*restapi.FireboxAPI, error) {
 	api.HealthIsHealthyHandler = s.httpProber.HealthyHandler()
 	api.HealthIsReadyHandler = s.httpProber.ReadyHandler()
 
-	mgr := manager.NewVMMManager(s.logger, *vmmConfig)
+	volumes, err := volume.NewStore(filepath.Join(vmmConfig.WorkDir, "volumes"), vmmConfig.MaxDiskSizeMib)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := snapshot.NewStore(filepath.Join(vmmConfig.WorkDir, "snapshots"))
+	if err != nil {
+		return nil, err
+	}
+	hibernated, err := snapshot.NewStore(filepath.Join(vmmConfig.WorkDir, "hibernated"))
+	if err != nil {
+		return nil, err
+	}
+	keyFile := serverConfig.SecretsKeyFile
+	if keyFile == "" {
+		keyFile = filepath.Join(vmmConfig.WorkDir, "secrets.key")
+	}
+	secrets, err := secret.NewStore(filepath.Join(vmmConfig.WorkDir, "secrets"), keyFile)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.AddHook(secrets.LogHook())
+	var bridges map[string]*network.IPAM
+	if !vmmConfig.Network.CNI.Enable {
+		if bridges, err = initBridges(s.logger); err != nil {
+			return nil, err
+		}
+	}
+	recorder := events.NewRecorder(serverConfig.EventsSize)
+	mgr := manager.NewVMMManager(s.logger, *vmmConfig, manager.Stores{
+		Volumes:    volumes,
+		Snapshots:  snapshots,
+		Hibernated: hibernated,
+		Secrets:    secrets,
+		Bridges:    bridges,
+	}, recorder)
 	mgr.Init(s.system)
 	s.defers.Add(func() {
 		_ = mgr.Close()
 	})
 	api.VMPostVMRunHandler = handlers.NewVMPostVMRunHandler(s.logger, mgr)
+	api.VMGetVMHandler = handlers.NewVMGetVMHandler(s.logger, mgr)
+	api.VMGetVMMetricsHandler = handlers.NewVMGetVMMetricsHandler(s.logger, mgr)
+	api.VMGetVMLogsHandler = handlers.NewVMGetVMLogsHandler(s.logger, mgr)
+	api.VMAttachVMConsoleHandler = handlers.NewVMAttachVMConsoleHandler(s.logger, mgr)
+	api.VMPutVMMetadataHandler = handlers.NewVMPutVMMetadataHandler(s.logger, mgr)
+	api.VMGetVMBalloonHandler = handlers.NewVMGetVMBalloonHandler(s.logger, mgr)
+	api.VMUpdateVMBalloonHandler = handlers.NewVMUpdateVMBalloonHandler(s.logger, mgr)
+	api.VMPauseVMHandler = handlers.NewVMPauseVMHandler(s.logger, mgr)
+	api.VMResumeVMHandler = handlers.NewVMResumeVMHandler(s.logger, mgr)
+	api.SnapshotCreateSnapshotHandler = handlers.NewSnapshotCreateSnapshotHandler(s.logger, mgr)
+	api.SnapshotListSnapshotsHandler = handlers.NewSnapshotListSnapshotsHandler(s.logger, snapshots)
+	api.SnapshotDeleteSnapshotHandler = handlers.NewSnapshotDeleteSnapshotHandler(s.logger, snapshots)
+	api.ProfileListProfilesHandler = handlers.NewProfileListProfilesHandler(s.logger, mgr)
+	api.SecretListSecretsHandler = handlers.NewSecretListSecretsHandler(s.logger, secrets)
+	api.SecretPutSecretHandler = handlers.NewSecretPutSecretHandler(s.logger, mgr)
+	api.SecretDeleteSecretHandler = handlers.NewSecretDeleteSecretHandler(s.logger, secrets)
+	api.VolumeListVolumesHandler = handlers.NewVolumeListVolumesHandler(s.logger, volumes)
+	api.VolumeCreateVolumeHandler = handlers.NewVolumeCreateVolumeHandler(s.logger, volumes)
+	api.VolumeDeleteVolumeHandler = handlers.NewVolumeDeleteVolumeHandler(s.logger, volumes)
+	api.EventsListEventsHandler = handlers.NewEventsListEventsHandler(s.logger, recorder)
+	api.NetworkListLeasesHandler = handlers.NewNetworkListLeasesHandler(s.logger, bridges)
 	api.ServiceInvokeHandler = handlers.NewServiceInvokeHandler(s.logger, mgr)
 	return api, nil
 }
@@ -23,26 +23,342 @@ type CNIConfig struct {
 	IfaceName   string
 }
 
+// BridgeConfig configures the built-in network used when CNI is disabled
+type BridgeConfig struct {
+	Name string
+	// Subnet of the guest IPs, the bridge gets the first address which is the gateway of the guests
+	Subnet string
+	// Subnet6 is the optional IPv6 subnet of the dual-stack guests, the bridge gets the first address.
+	// A guest gets the address at the offset of its IP in Subnet.
+	Subnet6 string
+	// Masquerade the traffic of the guests leaving the subnet
+	Masquerade bool
+	// Nameservers of the guests, at most two
+	Nameservers []string
+}
+
+// InterfaceConfig is a network interface of a machine
+type InterfaceConfig struct {
+	// Network is the name of the CNI network list when CNI is enabled, otherwise the name of the bridge
+	Network string
+	// AllowMMDS serves the MMDS on the interface when MMDS is enabled
+	AllowMMDS bool
+	// NetworkRx and NetworkTx default to the rate limits of the machine
+	NetworkRx *RateLimiterConfig
+	NetworkTx *RateLimiterConfig
+	// IP of the guest, allocated by the caller on a bridge and requested from the CNI IPAM when set.
+	// On CNI the IPs of a dual-stack guest are separated by commas.
+	IP string
+
+	// The CNI capability args are passed to the plugins of the CNI network declaring the capabilities.
+	// PortMappings expose guest ports on the host by the portmap plugin
+	PortMappings []PortMapping
+	// Bandwidth limits the traffic of the interface by the bandwidth plugin
+	Bandwidth *BandwidthConfig
+	// IPs are the guest IPs in CIDR notation requested from the IPAM plugin
+	IPs []string
+	// MAC is the guest MAC address
+	MAC string
+}
+
+// PortMapping forwards a port of the host to a port of the guest
+type PortMapping struct {
+	HostPort  int
+	GuestPort int
+	// Protocol is one of tcp, udp and sctp, tcp by default
+	Protocol string
+	// HostIP restricts the mapping to an IP of the host
+	HostIP string
+}
+
+// BandwidthConfig limits the rates in bits per second, the bursts are in bits
+type BandwidthConfig struct {
+	IngressRate  int64
+	IngressBurst int64
+	EgressRate   int64
+	EgressBurst  int64
+}
+
+type ConsoleConfig struct {
+	BufferSize  int
+	LogMaxSize  int64
+	LogMaxFiles int
+	// Attach connects the console to the stdin and stdout of the firebox process
+	Attach bool
+}
+
+// DriveConfig describes an additional block device of a machine.
+// A drive without Path is a scratch disk of SizeMib created for the machine.
+type DriveConfig struct {
+	Path     string
+	SizeMib  int64
+	ReadOnly bool
+	// Volume is the name of the persistent volume the Path belongs to
+	Volume string
+}
+
+// RestoreConfig starts a machine from a snapshot instead of booting the kernel
+type RestoreConfig struct {
+	// Dir holds the memory file, the machine state and the copies of the machine drives
+	Dir string
+	// Files are the names of the drive copies in Dir, the first one is the root drive
+	Files []string
+	// Drives are shared with the host and attached as they are
+	Drives []DriveConfig
+	// IP the guest was configured with when the snapshot was taken
+	IP string
+	// Interfaces of the machine with the IPs of the guest
+	Interfaces []InterfaceConfig
+	// Metadata of the machine the snapshot was taken from
+	Metadata *MetadataConfig
+	// Source is the name of the snapshot the machine the snapshot was taken from was started from
+	Source string
+}
+
+// MetadataConfig is written to the MMDS of a machine together with its VMID and IP
+type MetadataConfig struct {
+	Service string
+	Labels  map[string]string
+	// Data is the metadata JSON supplied by the caller
+	Data interface{}
+}
+
+type HibernationConfig struct {
+	// IdleTimeout after which a VM without invocations is hibernated, zero disables the hibernation
+	IdleTimeout   time.Duration
+	CheckInterval time.Duration
+}
+
+// BalloonConfig configures the memory balloon device of the machines
+type BalloonConfig struct {
+	Enable bool
+	// AmountMib is the size of the balloon the machine boots with
+	AmountMib    int64
+	DeflateOnOOM bool
+	// StatsInterval is the polling interval of the guest memory statistics, zero disables the statistics
+	StatsInterval time.Duration
+	// ReclaimIdleTimeout after which the balloon of a VM without invocations is inflated to ReclaimMib,
+	// zero disables the reclaim
+	ReclaimIdleTimeout   time.Duration
+	ReclaimMib           int64
+	ReclaimCheckInterval time.Duration
+}
+
+// TokenBucketConfig refills Size tokens every RefillTime, OneTimeBurst tokens are available initially on top of it
+type TokenBucketConfig struct {
+	Size         int64
+	OneTimeBurst int64
+	RefillTime   time.Duration
+}
+
+// RateLimiterConfig limits the bandwidth in bytes and the number of operations
+type RateLimiterConfig struct {
+	Bandwidth *TokenBucketConfig
+	Ops       *TokenBucketConfig
+}
+
+// RateLimitsConfig limits the I/O of a machine, every drive and network interface has its own rate limiter
+type RateLimitsConfig struct {
+	Drive *RateLimiterConfig
+	// NetworkRx limits the traffic received by the guest
+	NetworkRx *RateLimiterConfig
+	// NetworkTx limits the traffic sent by the guest
+	NetworkTx *RateLimiterConfig
+}
+
+const (
+	EnvDeliveryMMDS  = "mmds"
+	EnvDeliveryDrive = "drive"
+)
+
+// EnvConfig delivers environment variables to the guest
+type EnvConfig struct {
+	// Vars include the resolved secret values, they must never be logged
+	Vars map[string]string
+	// Delivery is one of EnvDeliveryMMDS or EnvDeliveryDrive
+	Delivery string
+}
+
+// BootProfile bundles the boot configuration of a machine, the empty fields keep the configured values
+type BootProfile struct {
+	KernelImage string
+	Initrd      string
+	KernelArgs  string
+	RootFS      string
+}
+
+// ApplyBootProfile overrides the boot configuration with the profile
+func ApplyBootProfile(c *VMMConfig, p BootProfile) {
+	if p.KernelImage != "" {
+		c.KernelImage = p.KernelImage
+	}
+	if p.Initrd != "" {
+		c.Initrd = p.Initrd
+	}
+	if p.KernelArgs != "" {
+		c.KernelArgs = p.KernelArgs
+	}
+	if p.RootFS != "" {
+		c.RootFS = p.RootFS
+	}
+}
+
+// DefaultInterface is attached to the CNI network or, with CNI disabled, to the bridge of the config
+func DefaultInterface(c *VMMConfig) InterfaceConfig {
+	network := c.Network.CNI.NetworkName
+	if !c.Network.CNI.Enable {
+		network = c.Network.Bridge.Name
+	}
+	return InterfaceConfig{Network: network, AllowMMDS: c.Network.AllowMMDS}
+}
+
+// RestoreInterfaces returns the interfaces of the machine the snapshot was taken from,
+// the snapshots without interfaces had the default interface
+func RestoreInterfaces(c *VMMConfig) []InterfaceConfig {
+	if len(c.Restore.Interfaces) > 0 {
+		return append([]InterfaceConfig{}, c.Restore.Interfaces...)
+	}
+	iface := DefaultInterface(c)
+	iface.IP = c.Restore.IP
+	return []InterfaceConfig{iface}
+}
+
+// LookupBridge returns the bridge of the config or the additional bridge with the given name
+func LookupBridge(c *VMMConfig, name string) (BridgeConfig, bool) {
+	if name == c.Network.Bridge.Name {
+		return c.Network.Bridge, true
+	}
+	b, ok := c.Network.Bridges[name]
+	return b, ok
+}
+
+// DefaultService is the service of the machines started without a service
+const DefaultService = "default"
+
+// KnownService returns true for the configured services and the DefaultService
+func KnownService(c *VMMConfig, name string) bool {
+	_, ok := c.Services[name]
+	return ok || name == DefaultService
+}
+
+const (
+	// NetworkRepairNone reports the failed network checks only
+	NetworkRepairNone = "none"
+	// NetworkRepairReattach attaches the interfaces on the built-in network again, it replaces the machines on CNI
+	NetworkRepairReattach = "reattach"
+	// NetworkRepairReplace stops the machine and starts a new one with the same spec
+	NetworkRepairReplace = "replace"
+)
+
+// NetworkCheckConfig configures the periodic check of the network attachments of the machines
+type NetworkCheckConfig struct {
+	// Interval of the checks, 0 disables the checks
+	Interval time.Duration
+	// Repair is one of NetworkRepairNone, NetworkRepairReattach and NetworkRepairReplace
+	Repair string
+}
+
+// NetworkPolicyConfig restricts the traffic of the machines to the allowed connections, the rest is dropped.
+// The replies of the allowed connections are allowed.
+type NetworkPolicyConfig struct {
+	// Egress are the destinations the guests may connect to, including the host and the other guests
+	Egress []NetworkPolicyRule
+	// Ingress are the sources which may connect to the guests, the host always may
+	Ingress []NetworkPolicyRule
+}
+
+// NetworkPolicyRule allows the connections to or from the CIDRs on the destination ports
+type NetworkPolicyRule struct {
+	// CIDRs of the destinations or the sources, any address when empty
+	CIDRs []string
+	// Ports of the destinations, a port or a range like 8000-8080, any port when empty
+	Ports []string
+	// Protocol is one of tcp, udp, sctp and icmp, tcp with ports and any protocol otherwise by default
+	Protocol string
+}
+
+// DNSConfig configures the DNS server of the guests listening on the gateways of their interfaces
+type DNSConfig struct {
+	Enable bool
+	// Domain of the names of the machines
+	Domain string
+	// TTL of the answers
+	TTL time.Duration
+	// Upstream nameservers the other queries are forwarded to, the nameservers of the host by default
+	Upstream []string
+}
+
+// ServiceConfig is applied to all machines of a service
+type ServiceConfig struct {
+	// Profile is the name of the boot profile of the machines
+	Profile    string
+	RateLimits RateLimitsConfig
+	// Labels of the machines, the labels given on start take precedence
+	Labels map[string]string
+	// Env are the environment variables of the guest
+	Env map[string]string
+	// Secrets map environment variables of the guest to the names of the secrets
+	Secrets map[string]string
+	// Interfaces replace the default network interface of the machines
+	Interfaces []InterfaceConfig
+	// EnvDelivery defaults to EnvDeliveryMMDS when MMDS is enabled and to EnvDeliveryDrive otherwise
+	EnvDelivery string
+	// NetworkPolicy applies to all interfaces of the machines instead of the default policy which allows no connections
+	NetworkPolicy *NetworkPolicyConfig
+}
+
 type VMMConfig struct {
 	SocketPath  string
 	LogLevel    string
 	DebugClient bool
 	RootFS      string
+	KeepRootFS  bool
 	KernelImage string
+	Initrd      string
 	KernelArgs  string
-	NetNS       string
-	Jailer      JailerConfig
-	Machine     struct {
+	// NetNS is the network namespace of the machine, by default every machine on CNI gets its own in NetNSDir
+	NetNS    string
+	NetNSDir string
+	WorkDir  string
+	// DataDir holds the data disks the machines started by the server may attach by path
+	DataDir    string
+	Drives     []DriveConfig
+	Restore    *RestoreConfig
+	Jailer     JailerConfig
+	Console    ConsoleConfig
+	Balloon    BalloonConfig
+	RateLimits RateLimitsConfig
+	Metadata   MetadataConfig
+	Env        EnvConfig
+	Machine    struct {
 		CPUTemplate string
 		HtEnabled   bool
 		MemSizeMib  int64
 		VcpuCount   int64
 	}
 	Network struct {
-		CNI       CNIConfig
+		CNI    CNIConfig
+		Bridge BridgeConfig
+		// Bridges are the additional bridges of the built-in network by name
+		Bridges   map[string]BridgeConfig
 		AllowMMDS bool
+		// Interfaces of the machine in the guest order, the IPs of the bridge interfaces are required
+		Interfaces []InterfaceConfig
 	}
 	VMM struct {
 		ShutdownTimeout time.Duration
 	}
+	Hibernation  HibernationConfig
+	NetworkCheck NetworkCheckConfig
+	// NetworkPolicy restricts the traffic of all interfaces of the machine, nil applies no policy.
+	// The server applies the empty policy allowing no connections to the services without a policy.
+	NetworkPolicy *NetworkPolicyConfig
+	// DNS makes the gateways of the interfaces the nameservers of the guest
+	DNS DNSConfig
+	// Services configure the machines by service name
+	Services map[string]ServiceConfig
+	// Profiles are the boot profiles by name
+	Profiles map[string]BootProfile
+	// MaxDiskSizeMib limits the size of the volumes and scratch disks created for the machines started by the server
+	MaxDiskSizeMib int64
 }
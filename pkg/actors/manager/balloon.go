@@ -0,0 +1,50 @@
+package manager
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/combust-labs/firebox/pkg/actors/ticker"
+)
+
+// initBalloonReclaim starts the check inflating the balloons of the idle machines
+func (m *VMMManager) initBalloonReclaim() {
+	cfg := m.vmmConfig.Balloon
+	if !cfg.Enable || cfg.ReclaimIdleTimeout <= 0 {
+		return
+	}
+	if cfg.ReclaimMib <= cfg.AmountMib || cfg.ReclaimMib > m.vmmConfig.Machine.MemSizeMib {
+		m.logger.Warnf("Balloon reclaim disabled: reclaim size %d Mib must be in the range (%d, %d]", cfg.ReclaimMib, cfg.AmountMib, m.vmmConfig.Machine.MemSizeMib)
+		return
+	}
+	interval := cfg.ReclaimCheckInterval
+	if interval <= 0 {
+		interval = cfg.ReclaimIdleTimeout
+	}
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return ticker.NewTickerActor(interval, m.reclaimIdle)
+	})
+	pid := m.rootContext.SpawnPrefix(props, "vmm-manager/balloon/")
+	m.rootContext.Send(pid, &ticker.Start{})
+}
+
+// reclaimIdle inflates the balloons of the machines without invocation for the reclaim idle timeout
+func (m *VMMManager) reclaimIdle() {
+	cfg := m.vmmConfig.Balloon
+	for _, e := range m.db.entries() {
+		if !m.db.reclaim(e.vmid, cfg.ReclaimIdleTimeout) {
+			continue
+		}
+		if err := m.setBalloon(e.vmid, cfg.ReclaimMib); err != nil {
+			m.logger.Errorf("Memory reclaim of vmid %s failed: %v", e.vmid, err)
+			m.db.unreclaim(e.vmid)
+			continue
+		}
+		m.logger.Infof("Machine RECLAIMED vmid: %v, balloon: %v Mib", e.vmid, cfg.ReclaimMib)
+	}
+}
+
+// deflate gives the memory reclaimed from an idle machine back before it serves an invocation
+func (m *VMMManager) deflate(e entry) {
+	if err := m.setBalloon(e.vmid, m.vmmConfig.Balloon.AmountMib); err != nil {
+		m.logger.Errorf("Balloon deflate of vmid %s failed: %v", e.vmid, err)
+	}
+}
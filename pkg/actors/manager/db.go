@@ -3,16 +3,47 @@ package manager
 import (
 	"fmt"
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/combust-labs/firebox/pkg/vmm"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"net"
 	"sync"
+	"time"
 )
 
 type entry struct {
-	vmid  string
-	pid   *actor.PID
-	ip    net.IP
-	ready bool
+	vmid    string
+	pid     *actor.PID
+	ip      net.IP
+	service string
+	labels  map[string]string
+	ready   bool
+	paused  bool
+	// hibernating machines are being snapshotted and stopped, they do not serve invocations anymore
+	hibernating bool
+	// reclaimed machines have the balloon inflated until they are used again
+	reclaimed bool
+	// volumes are the names of the persistent volumes attached to the machine
+	volumes []string
+	// interfaces are the network interfaces of the machine, ip is the guest IP of the first one
+	interfaces []vmm.Interface
+	// networkErr is the error of the last failed network check, nil while the checks succeed
+	networkErr error
+	// spec the machine was started with, a replacement is started with it
+	spec VMSpec
+
+	created time.Time
+	readyAt time.Time
+	// readyChanged and networkChanged are the times of the last transitions of the conditions
+	readyChanged   time.Time
+	networkChanged time.Time
+	// lastUsed is the time the machine last served an invocation
+	lastUsed time.Time
+	// invocations is the number of the invocations the machine is serving
+	invocations int
+	// bootSpan lasts from the start request until the machine becomes ready for the first time, stops or
+	// exceeds the boot timeout
+	bootSpan trace.Span
 }
 
 func (e entry) String() string {
@@ -50,7 +81,7 @@ func (db *db) entries() (result []entry) {
 	return
 }
 
-func (db *db) add(vmid string, pid *actor.PID, ip net.IP) error {
+func (db *db) add(vmid string, pid *actor.PID, ip net.IP, interfaces []vmm.Interface, spec VMSpec, labels map[string]string, volumes []string, created time.Time, bootSpan trace.Span) error {
 	db.Lock()
 	defer db.Unlock()
 
@@ -65,31 +96,177 @@ func (db *db) add(vmid string, pid *actor.PID, ip net.IP) error {
 		return errors.Errorf("vmid '%s' has already been added", vmid)
 	}
 	db.machines[vmid] = entry{
-		vmid: vmid,
-		pid:  pid,
-		ip:   ip,
+		vmid:       vmid,
+		pid:        pid,
+		ip:         ip,
+		interfaces: interfaces,
+		spec:       spec,
+		service:    spec.Service,
+		labels:     labels,
+		volumes:    volumes,
+		created:    created,
+		lastUsed:   created,
+		bootSpan:   bootSpan,
+
+		readyChanged:   created,
+		networkChanged: created,
 	}
 	return nil
 }
 
-func (db *db) del(vmid string) *actor.PID {
+func (db *db) del(vmid string) *entry {
 	db.Lock()
 	defer db.Unlock()
 
 	if entry, ok := db.machines[vmid]; ok {
 		delete(db.machines, vmid)
-		return entry.pid
+		return &entry
 	}
 	return nil
 }
 
-func (db *db) ready(vmid string, ready bool) {
+// ready returns the updated entry and true when the machine becomes ready for the first time
+func (db *db) ready(vmid string, ready bool) (*entry, bool) {
 	db.Lock()
 	defer db.Unlock()
 
 	entry, ok := db.machines[vmid]
-	if ok {
-		entry.ready = ready
+	if !ok {
+		return nil, false
+	}
+	if entry.ready != ready {
+		entry.readyChanged = time.Now()
+	}
+	entry.ready = ready
+	first := ready && entry.readyAt.IsZero()
+	if first {
+		entry.readyAt = time.Now()
+	}
+	db.machines[vmid] = entry
+	return &entry, first
+}
+
+// network records the result of a network check, it returns true when the machine changes between failing and passing checks
+func (db *db) network(vmid string, err error) bool {
+	db.Lock()
+	defer db.Unlock()
+
+	entry, ok := db.machines[vmid]
+	if !ok {
+		return false
+	}
+	changed := (entry.networkErr == nil) != (err == nil)
+	if changed {
+		entry.networkChanged = time.Now()
+	}
+	entry.networkErr = err
+	db.machines[vmid] = entry
+	return changed
+}
+
+func (db *db) pause(vmid string, paused bool) {
+	db.Lock()
+	defer db.Unlock()
+
+	if entry, ok := db.machines[vmid]; ok {
+		entry.paused = paused
+		db.machines[vmid] = entry
+	}
+}
+
+// use marks the machine as serving an invocation until done is called, it fails when the machine is being hibernated
+func (db *db) use(vmid string) bool {
+	db.Lock()
+	defer db.Unlock()
+
+	entry, ok := db.machines[vmid]
+	if !ok || entry.hibernating {
+		return false
+	}
+	entry.invocations++
+	entry.lastUsed = time.Now()
+	db.machines[vmid] = entry
+	return true
+}
+
+// done marks the invocation served by the machine as finished
+func (db *db) done(vmid string) {
+	db.Lock()
+	defer db.Unlock()
+
+	if entry, ok := db.machines[vmid]; ok {
+		entry.invocations--
+		entry.lastUsed = time.Now()
 		db.machines[vmid] = entry
 	}
 }
+
+// hibernate marks the ready machine which is not paused and did not serve invocations for the idle timeout
+// as hibernating
+func (db *db) hibernate(vmid string, idleTimeout time.Duration) bool {
+	db.Lock()
+	defer db.Unlock()
+
+	entry, ok := db.machines[vmid]
+	if !ok || !entry.ready || entry.paused || entry.hibernating || entry.invocations > 0 || time.Since(entry.lastUsed) < idleTimeout {
+		return false
+	}
+	entry.hibernating = true
+	db.machines[vmid] = entry
+	return true
+}
+
+// reclaim marks the ready machine which did not serve invocations for the idle timeout as reclaimed
+func (db *db) reclaim(vmid string, idleTimeout time.Duration) bool {
+	db.Lock()
+	defer db.Unlock()
+
+	entry, ok := db.machines[vmid]
+	if !ok || !entry.ready || entry.paused || entry.hibernating || entry.reclaimed || entry.invocations > 0 || time.Since(entry.lastUsed) < idleTimeout {
+		return false
+	}
+	entry.reclaimed = true
+	db.machines[vmid] = entry
+	return true
+}
+
+// unreclaim clears the reclaimed mark, it returns true when the machine was reclaimed
+func (db *db) unreclaim(vmid string) bool {
+	db.Lock()
+	defer db.Unlock()
+
+	entry, ok := db.machines[vmid]
+	if !ok || !entry.reclaimed {
+		return false
+	}
+	entry.reclaimed = false
+	db.machines[vmid] = entry
+	return true
+}
+
+// wake clears the hibernating mark of a machine which failed to hibernate
+func (db *db) wake(vmid string) {
+	db.Lock()
+	defer db.Unlock()
+
+	if entry, ok := db.machines[vmid]; ok {
+		entry.hibernating = false
+		db.machines[vmid] = entry
+	}
+}
+
+func (db *db) readiness() (ready int, unready int, paused int) {
+	db.Lock()
+	defer db.Unlock()
+
+	for _, entry := range db.machines {
+		if entry.paused {
+			paused++
+		} else if entry.ready {
+			ready++
+		} else {
+			unready++
+		}
+	}
+	return
+}
@@ -0,0 +1,111 @@
+package manager
+
+import (
+	"net"
+	"strings"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/dns"
+	"github.com/combust-labs/firebox/pkg/network"
+)
+
+// initDNS starts the DNS server of the guests on the gateways of the bridges of the built-in network,
+// the machines start it on the gateways of the CNI networks. The guests keep the configured nameservers
+// when the DNS server fails.
+func (m *VMMManager) initDNS() {
+	if !m.vmmConfig.DNS.Enable {
+		return
+	}
+	if err := m.startDNS(); err != nil {
+		m.logger.Errorf("DNS server disabled, the guests keep their nameservers: %v", err)
+		m.vmmConfig.DNS.Enable = false
+		if m.dns != nil {
+			_ = m.dns.Close()
+			m.dns = nil
+		}
+	}
+}
+
+func (m *VMMManager) startDNS() error {
+	server, err := dns.NewServer(m.logger, m.vmmConfig.DNS, resolver{db: m.db})
+	if err != nil {
+		return err
+	}
+	m.dns = server
+	for name := range m.stores.Bridges {
+		bridge, ok := config.LookupBridge(&m.vmmConfig, name)
+		if !ok {
+			continue
+		}
+		subnet, gateway, err := network.ParseSubnet(bridge.Subnet)
+		if err != nil {
+			return err
+		}
+		if err := server.Listen(net.IPNet{IP: gateway, Mask: subnet.Mask}); err != nil {
+			return err
+		}
+		if bridge.Subnet6 == "" {
+			continue
+		}
+		subnet6, gateway6, err := network.ParseSubnet6(bridge.Subnet6, subnet)
+		if err != nil {
+			return err
+		}
+		if err := server.Listen(net.IPNet{IP: gateway6, Mask: subnet6.Mask}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolver resolves the names of the DNS server to the IPs of the first interface of the machines
+type resolver struct {
+	db *db
+}
+
+func (r resolver) VM(vmid string) []net.IP {
+	for _, e := range r.db.entries() {
+		if strings.EqualFold(e.vmid, vmid) {
+			return entryIPs(e)
+		}
+	}
+	return nil
+}
+
+func (r resolver) Service(name string) []net.IP {
+	return r.ready(func(e entry) bool {
+		return strings.EqualFold(e.service, name)
+	})
+}
+
+func (r resolver) Label(key, value string) []net.IP {
+	return r.ready(func(e entry) bool {
+		for k, v := range e.labels {
+			if strings.EqualFold(k, key) && strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ready returns the IPs of the machines serving invocations which match
+func (r resolver) ready(match func(e entry) bool) []net.IP {
+	var result []net.IP
+	for _, e := range r.db.entries() {
+		if e.ready && !e.paused && !e.hibernating && match(e) {
+			result = append(result, entryIPs(e)...)
+		}
+	}
+	return result
+}
+
+func entryIPs(e entry) []net.IP {
+	if len(e.interfaces) > 0 {
+		return e.interfaces[0].IPs()
+	}
+	if e.ip != nil {
+		return []net.IP{e.ip}
+	}
+	return nil
+}
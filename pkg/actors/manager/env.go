@@ -0,0 +1,102 @@
+package manager
+
+import (
+	"regexp"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/actors/vmm"
+	"github.com/combust-labs/firebox/pkg/secret"
+	"github.com/pkg/errors"
+)
+
+var validEnvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// env resolves the environment of the machines of the service
+func (m *VMMManager) env(svc config.ServiceConfig) (config.EnvConfig, error) {
+	env := config.EnvConfig{
+		Vars:     make(map[string]string, len(svc.Env)+len(svc.Secrets)),
+		Delivery: svc.EnvDelivery,
+	}
+	if env.Delivery == "" {
+		env.Delivery = config.EnvDeliveryDrive
+		if m.vmmConfig.Network.AllowMMDS {
+			env.Delivery = config.EnvDeliveryMMDS
+		}
+	}
+	switch env.Delivery {
+	case config.EnvDeliveryDrive:
+	case config.EnvDeliveryMMDS:
+		if !m.vmmConfig.Network.AllowMMDS && len(svc.Env)+len(svc.Secrets) > 0 {
+			return env, errors.New("environment delivery by MMDS requires MMDS")
+		}
+	default:
+		return env, errors.Errorf("unknown environment delivery '%s'", env.Delivery)
+	}
+	for name, value := range svc.Env {
+		if !validEnvName.MatchString(name) {
+			return env, errors.Errorf("invalid environment variable name '%s'", name)
+		}
+		env.Vars[name] = value
+	}
+	for name, secretName := range svc.Secrets {
+		if !validEnvName.MatchString(name) {
+			return env, errors.Errorf("invalid environment variable name '%s'", name)
+		}
+		value, err := m.stores.Secrets.Value(secretName)
+		if err != nil {
+			return env, errors.Wrapf(err, "environment variable %s", name)
+		}
+		env.Vars[name] = value
+	}
+	return env, nil
+}
+
+// PutSecret stores the secret, a rotated secret is delivered to the running machines using it through the MMDS.
+// The machines with the environment on the config drive keep the previous value until they are restarted.
+func (m *VMMManager) PutSecret(name, value string) (*secret.Secret, error) {
+	s, err := m.stores.Secrets.Put(name, value)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range m.db.entries() {
+		svc := m.vmmConfig.Services[e.service]
+		if !usesSecret(svc, name) {
+			continue
+		}
+		env, err := m.env(svc)
+		if err != nil {
+			m.logger.Errorf("Environment of vmid %s not updated: %v", e.vmid, err)
+			continue
+		}
+		if err := m.setEnv(e.vmid, env.Vars); err != nil {
+			m.logger.Warnf("Environment of vmid %s not updated: %v", e.vmid, err)
+		}
+	}
+	m.logger.Infof("Secret '%s' stored, version %d", name, s.Version)
+	return s, nil
+}
+
+func usesSecret(svc config.ServiceConfig, name string) bool {
+	for _, secretName := range svc.Secrets {
+		if secretName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *VMMManager) setEnv(vmid string, vars map[string]string) error {
+	result, err := m.requestVM(vmid, &vmm.SetEnv{Vars: vars})
+	if err != nil {
+		return err
+	}
+	switch msg := result.(type) {
+	case *vmm.EnvSet:
+		m.logger.Infof("Machine ENV updated vmid: %v", msg.ID)
+		return nil
+	case *vmm.Failure:
+		return msg.Err
+	default:
+		return errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
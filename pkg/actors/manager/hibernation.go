@@ -0,0 +1,208 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/combust-labs/firebox/pkg/actors/ticker"
+	"github.com/combust-labs/firebox/pkg/actors/vmm"
+	"github.com/combust-labs/firebox/pkg/events"
+	"github.com/combust-labs/firebox/pkg/snapshot"
+	"github.com/pkg/errors"
+)
+
+// restoreTimeout limits the wait for a restored machine to become ready
+const restoreTimeout = 30 * time.Second
+
+// hibernation keeps the snapshots of the hibernated machines by service
+type hibernation struct {
+	sync.Mutex
+	snapshots map[string][]*snapshot.Snapshot
+	// restoring counts the restores by service which did not finish yet
+	restoring map[string]int
+}
+
+func newHibernation() *hibernation {
+	return &hibernation{
+		snapshots: make(map[string][]*snapshot.Snapshot),
+		restoring: make(map[string]int),
+	}
+}
+
+func (h *hibernation) push(s *snapshot.Snapshot) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.snapshots[s.Service] = append(h.snapshots[s.Service], s)
+}
+
+// pop takes a snapshot of the service to be restored, done must be called once the restore finished
+func (h *hibernation) pop(service string) *snapshot.Snapshot {
+	h.Lock()
+	defer h.Unlock()
+
+	snapshots := h.snapshots[service]
+	if len(snapshots) == 0 {
+		return nil
+	}
+	s := snapshots[len(snapshots)-1]
+	h.snapshots[service] = snapshots[:len(snapshots)-1]
+	h.restoring[service]++
+	return s
+}
+
+func (h *hibernation) done(service string) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.restoring[service]--; h.restoring[service] <= 0 {
+		delete(h.restoring, service)
+	}
+}
+
+func (h *hibernation) isRestoring(service string) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	return h.restoring[service] > 0
+}
+
+// initHibernation loads the machines hibernated by a previous run and starts the idle check
+func (m *VMMManager) initHibernation() {
+	snapshots, err := m.stores.Hibernated.List()
+	if err != nil {
+		m.logger.Errorf("Loading hibernated machines failed: %v", err)
+	}
+	for i := range snapshots {
+		if err := m.reserveHibernatedIPs(&snapshots[i]); err != nil {
+			m.logger.Errorf("Hibernated vmid %s can not be restored: %v", snapshots[i].VMID, err)
+			continue
+		}
+		m.hibernation.push(&snapshots[i])
+	}
+	if len(snapshots) > 0 {
+		m.logger.Infof("Hibernated machines loaded: %v", len(snapshots))
+	}
+
+	cfg := m.vmmConfig.Hibernation
+	if cfg.IdleTimeout <= 0 {
+		return
+	}
+	if m.vmmConfig.Jailer.Enable {
+		m.logger.Warnf("Hibernation disabled: snapshots are not supported with the jailer")
+		return
+	}
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = cfg.IdleTimeout
+	}
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return ticker.NewTickerActor(interval, m.hibernateIdle)
+	})
+	pid := m.rootContext.SpawnPrefix(props, "vmm-manager/hibernation/")
+	m.rootContext.Send(pid, &ticker.Start{})
+}
+
+// hibernateIdle hibernates the machines without invocation for the idle timeout
+func (m *VMMManager) hibernateIdle() {
+	for _, e := range m.db.entries() {
+		if len(e.volumes) > 0 {
+			// the persistent volumes can not be part of a snapshot
+			continue
+		}
+		if !m.db.hibernate(e.vmid, m.vmmConfig.Hibernation.IdleTimeout) {
+			continue
+		}
+		if err := m.hibernate(e); err != nil {
+			m.logger.Errorf("Hibernation of vmid %s failed: %v", e.vmid, err)
+			m.db.wake(e.vmid)
+		}
+	}
+}
+
+func (m *VMMManager) hibernate(e entry) error {
+	s, err := m.stores.Hibernated.Create(e.vmid, e.vmid, e.service, m.takeSnapshot(e))
+	if err != nil {
+		return err
+	}
+	// the machine state is on disk, the machine does not need to shut down gracefully
+	if _, err := m.rootContext.RequestFuture(e.pid, &vmm.Stop{}, m.vmmConfig.VMM.ShutdownTimeout+5*time.Second).Result(); err != nil {
+		// the machine may still run, it stays in the inventory with its IPs and is not hibernated
+		if derr := m.stores.Hibernated.Delete(s.Name); derr != nil {
+			m.logger.Warnf("Failed to delete snapshot of vmid %v: %v", e.vmid, derr)
+		}
+		return errors.Wrap(err, "stopping machine failed")
+	}
+	m.remove(e.vmid)
+	m.hibernation.push(s)
+	m.logger.Infof("Machine HIBERNATED vmid: %v, service: %v", e.vmid, e.service)
+	m.events.Record(events.Event{
+		Type:    events.Hibernated,
+		VMID:    e.vmid,
+		Service: e.service,
+		Message: fmt.Sprintf("no invocation since %s", e.lastUsed.UTC().Format(time.RFC3339)),
+	})
+	return nil
+}
+
+// restoreHibernated restores a hibernated machine of the service and waits until a machine of the service is ready,
+// the machine is picked to serve an invocation. It returns false when there is no hibernated machine of the service.
+func (m *VMMManager) restoreHibernated(ctx context.Context, service string) (*entry, bool, error) {
+	s := m.hibernation.pop(service)
+	if s == nil {
+		if !m.hibernation.isRestoring(service) {
+			return nil, false, nil
+		}
+		// a machine is being restored by another invocation
+		e, err := m.waitService(ctx, service)
+		return e, true, err
+	}
+	defer m.hibernation.done(service)
+
+	// the replacement of the restored machine is started like the hibernated machine was
+	spec := VMSpec{Service: service, Snapshot: s.Source, restore: s}
+	if s.Metadata != nil {
+		spec.Labels = s.Metadata.Labels
+		spec.Metadata = s.Metadata.Data
+	}
+	machine, err := m.StartVMM(ctx, spec)
+	if err != nil {
+		m.hibernation.push(s)
+		return nil, true, errors.Wrapf(err, "restoring hibernated vmid %s failed", s.VMID)
+	}
+	// the restored machine does not depend on the snapshot
+	if err := m.stores.Hibernated.Delete(s.Name); err != nil {
+		m.logger.Warnf("Failed to delete snapshot of hibernated vmid %v: %v", s.VMID, err)
+	}
+	m.logger.Infof("Machine RESTORED vmid: %v, service: %v, hibernated vmid: %v", machine.ID, service, s.VMID)
+	m.events.Record(events.Event{
+		Type:    events.Restored,
+		VMID:    machine.ID,
+		Service: service,
+		Message: fmt.Sprintf("restored hibernated vmid %s", s.VMID),
+	})
+	e, err := m.waitService(ctx, service)
+	return e, true, err
+}
+
+// waitService waits until a machine of the service becomes ready and picks it to serve an invocation
+func (m *VMMManager) waitService(ctx context.Context, service string) (*entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, restoreTimeout)
+	defer cancel()
+
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "No machine of service '%s' became READY", service)
+		case <-t.C:
+			if e, err := m.pickService(service); err == nil {
+				return e, nil
+			}
+		}
+	}
+}
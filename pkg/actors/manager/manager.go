@@ -4,42 +4,145 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"fmt"
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/combust-labs/firebox/api/models"
 	"github.com/combust-labs/firebox/config"
 	"github.com/combust-labs/firebox/pkg/actors/vmm"
+	"github.com/combust-labs/firebox/pkg/console"
+	"github.com/combust-labs/firebox/pkg/dns"
+	"github.com/combust-labs/firebox/pkg/events"
 	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/combust-labs/firebox/pkg/metrics"
+	"github.com/combust-labs/firebox/pkg/network"
+	"github.com/combust-labs/firebox/pkg/secret"
+	"github.com/combust-labs/firebox/pkg/snapshot"
+	"github.com/combust-labs/firebox/pkg/tracing"
+	vmmpkg "github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/combust-labs/firebox/pkg/volume"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"io"
 	"io/ioutil"
 	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 )
 
+const DefaultService = config.DefaultService
+
+var (
+	ErrVMNotFound       = errors.New("VM not found")
+	ErrProfileNotFound  = errors.New("boot profile not found")
+	ErrNetworkNotFound  = errors.New("network not found")
+	ErrInvalidInterface = errors.New("invalid network interface")
+	ErrInvalidDrive     = errors.New("invalid drive")
+	// ErrInterfacesWithSnapshot is returned for the interfaces of a machine restored from a snapshot
+	ErrInterfacesWithSnapshot = errors.New("network interfaces are taken from the snapshot")
+)
+
+// VMSpec describes a machine to be started on top of the VMM config of the manager
+type VMSpec struct {
+	// Service defaults to the service of the snapshot or DefaultService
+	Service string
+	// Drives are attached after the drives of the VMM config, the data disks must be in the data dir
+	Drives []config.DriveConfig
+	// Snapshot is the name of the snapshot the machine is restored from instead of booting it
+	Snapshot string
+	// Profile is the name of the boot profile, it defaults to the profile of the service
+	Profile string
+	// Labels are added to the labels of the service
+	Labels map[string]string
+	// Metadata is the JSON the guest reads from the MMDS
+	Metadata interface{}
+	// Interfaces replace the network interfaces of the service
+	Interfaces []config.InterfaceConfig
+
+	// restore is the snapshot of a hibernated machine
+	restore *snapshot.Snapshot
+}
+
+// Stores keep the state which outlives the machines
+type Stores struct {
+	Volumes   *volume.Store
+	Snapshots *snapshot.Store
+	// Hibernated keeps the snapshots of the hibernated machines
+	Hibernated *snapshot.Store
+	Secrets    *secret.Store
+	// Bridges allocate the guest IPs of the built-in network by bridge name, they are empty when CNI is enabled
+	Bridges map[string]*network.IPAM
+}
+
+// VM is a machine started by the manager
+type VM struct {
+	vmm.Metadata
+	Service string
+	Labels  map[string]string
+	// Conditions are the ConditionReady and the ConditionNetworkReady of a running machine
+	Conditions []Condition
+}
+
+const (
+	// ConditionReady is true while the health probe of the machine succeeds
+	ConditionReady = "Ready"
+	// ConditionNetworkReady is false while the check of the network attachments of the machine fails
+	ConditionNetworkReady = "NetworkReady"
+)
+
+// Condition is a state of a machine
+type Condition struct {
+	Type   string
+	Status bool
+	// Message tells why the condition is false
+	Message string
+	// LastTransition is the time the status changed
+	LastTransition time.Time
+}
+
+var tracer = tracing.Tracer("github.com/combust-labs/firebox/pkg/actors/manager")
+
+// bootTimeout ends the boot span of a machine which is not ready in time, the machine keeps running
+const bootTimeout = 5 * time.Minute
+
+// bootExpired is sent to the manager when the boot timeout of a machine elapses
+type bootExpired struct {
+	vmid string
+}
+
 type VMMManager struct {
 	actor.Actor
 
 	init sync.Once
 
-	logger    *log.Logger
-	vmmConfig config.VMMConfig
-	db        *db
+	logger      *log.Logger
+	vmmConfig   config.VMMConfig
+	stores      Stores
+	events      *events.Recorder
+	db          *db
+	hibernation *hibernation
+	// dns is the DNS server of the guests, nil when disabled
+	dns *dns.Server
 
 	rootContext *actor.RootContext
 	self        *actor.PID
 }
 
-func NewVMMManager(logger *log.Logger, vmmConfig config.VMMConfig) *VMMManager {
+func NewVMMManager(logger *log.Logger, vmmConfig config.VMMConfig, stores Stores, recorder *events.Recorder) *VMMManager {
 	return &VMMManager{
-		logger:    logger,
-		vmmConfig: vmmConfig,
-		db:        initdb(),
+		logger:      logger,
+		vmmConfig:   vmmConfig,
+		stores:      stores,
+		events:      recorder,
+		db:          initdb(),
+		hibernation: newHibernation(),
 	}
 }
 
@@ -48,6 +151,11 @@ func (m *VMMManager) Init(system *actor.ActorSystem) {
 		props := actor.PropsFromProducer(func() actor.Actor { return m })
 		m.rootContext = system.Root
 		m.self = system.Root.SpawnPrefix(props, "vmm-manager")
+		m.initLeases()
+		m.initHibernation()
+		m.initBalloonReclaim()
+		m.initNetworkCheck()
+		m.initDNS()
 	})
 }
 
@@ -55,18 +163,158 @@ func (m *VMMManager) Receive(context actor.Context) {
 	switch msg := context.Message().(type) {
 	case *vmm.Stopped:
 		m.logger.Infof("Unexpected termination vmid %v", msg.ID)
-		m.db.del(msg.ID)
+		m.remove(msg.ID)
 	case *vmm.Ready:
 		m.logger.Infof("Machine READY vmid: %v, ip: %v", msg.ID, msg.IP)
-		m.db.ready(msg.ID, true)
+		if e, first := m.db.ready(msg.ID, true); first {
+			metrics.VMBootToReady.Observe(e.readyAt.Sub(e.created).Seconds())
+			e.bootSpan.End()
+		}
+		m.updateVMMetrics()
 	case *vmm.Unready:
-		m.logger.Warn("Machine UNREADY vmid: %v, ip: %v", msg.ID, msg.IP)
+		m.logger.Warnf("Machine UNREADY vmid: %v, ip: %v", msg.ID, msg.IP)
 		m.db.ready(msg.ID, false)
+		m.updateVMMetrics()
+	case *bootExpired:
+		// ending an ended span has no effect, the machine may become ready or stop meanwhile
+		if e := m.db.entry(msg.vmid); e != nil && e.readyAt.IsZero() {
+			m.logger.Warnf("Machine not ready after %v vmid: %v", bootTimeout, msg.vmid)
+			tracing.End(e.bootSpan, errors.Errorf("machine not ready after %v", bootTimeout))
+		}
 	}
 }
 
-func (m *VMMManager) StartVMM() (*vmm.Metadata, error) {
-	props := actor.PropsFromProducer(func() actor.Actor { return vmm.NewVMMActor(m.logger, m.vmmConfig) })
+func (m *VMMManager) updateVMMetrics() {
+	metrics.SetVMs(m.db.readiness())
+}
+
+// remove forgets the machine, the machine must have been stopped already
+func (m *VMMManager) remove(vmid string) {
+	if e := m.db.del(vmid); e != nil {
+		if e.readyAt.IsZero() {
+			tracing.End(e.bootSpan, errors.New("machine stopped before becoming ready"))
+		}
+		m.releaseVolumes(e.volumes)
+		// a hibernated machine keeps its IPs to be restored with it
+		if !e.hibernating {
+			for _, iface := range e.interfaces {
+				m.releaseIP(iface.Network, iface.IP.IP, e.vmid)
+			}
+		}
+	}
+	m.updateVMMetrics()
+}
+
+func (m *VMMManager) StartVMM(ctx context.Context, spec VMSpec) (*VM, error) {
+	metrics.VMStarts.Inc()
+	ctx, bootSpan := tracer.Start(ctx, "vm.boot")
+	machine, err := m.startVMM(ctx, spec, bootSpan)
+	if err != nil {
+		metrics.VMStartFailures.Inc()
+		tracing.End(bootSpan, err)
+		return nil, err
+	}
+	return machine, nil
+}
+
+func (m *VMMManager) startVMM(ctx context.Context, spec VMSpec, bootSpan trace.Span) (machine *VM, err error) {
+	_, span := tracer.Start(ctx, "vm.start")
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	created := time.Now()
+	vmmConfig := m.vmmConfig
+	if spec.Drives, err = m.dataDrives(spec.Drives); err != nil {
+		return nil, err
+	}
+	vmmConfig.Drives = append(append([]config.DriveConfig{}, m.vmmConfig.Drives...), spec.Drives...)
+	if s := spec.restore; s != nil {
+		vmmConfig.Restore = s.RestoreConfig()
+		vmmConfig.Drives = vmmConfig.Restore.Drives
+		bootSpan.SetAttributes(attribute.String("firebox.hibernated_vmid", s.VMID))
+	} else if spec.Snapshot != "" {
+		s, err := m.stores.Snapshots.Get(spec.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+		vmmConfig.Restore = s.RestoreConfig()
+		// the drives are part of the snapshot
+		vmmConfig.Drives = vmmConfig.Restore.Drives
+		if spec.Service == "" {
+			spec.Service = s.Service
+		}
+		bootSpan.SetAttributes(attribute.String("firebox.snapshot", spec.Snapshot))
+	}
+	if spec.Service == "" {
+		spec.Service = DefaultService
+	}
+	if spec.Metadata != nil && !m.vmmConfig.Network.AllowMMDS {
+		return nil, vmmpkg.ErrMMDSDisabled
+	}
+	svc := m.vmmConfig.Services[spec.Service]
+	if spec.Profile == "" {
+		spec.Profile = svc.Profile
+	}
+	if spec.Profile != "" && vmmConfig.Restore == nil {
+		p, ok := m.vmmConfig.Profiles[spec.Profile]
+		if !ok {
+			return nil, errors.Wrapf(ErrProfileNotFound, "'%s'", spec.Profile)
+		}
+		config.ApplyBootProfile(&vmmConfig, p)
+		bootSpan.SetAttributes(attribute.String("firebox.profile", spec.Profile))
+	}
+	// the restored machines keep the rate limiters of the snapshot
+	if vmmConfig.Restore == nil {
+		vmmConfig.RateLimits = svc.RateLimits
+	}
+	if svc.NetworkPolicy != nil {
+		vmmConfig.NetworkPolicy = svc.NetworkPolicy
+	}
+	labels := make(map[string]string)
+	for k, v := range svc.Labels {
+		labels[k] = v
+	}
+	for k, v := range spec.Labels {
+		labels[k] = v
+	}
+	vmmConfig.Metadata = config.MetadataConfig{
+		Service: spec.Service,
+		Labels:  labels,
+		Data:    spec.Metadata,
+	}
+	if vmmConfig.Env, err = m.env(svc); err != nil {
+		return nil, err
+	}
+	bootSpan.SetAttributes(attribute.String("firebox.service", spec.Service))
+
+	volumes, err := m.reserveVolumes(vmmConfig.Drives)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			m.releaseVolumes(volumes)
+		}
+	}()
+
+	if vmmConfig.Network.Interfaces, err = m.interfaces(spec, svc, &vmmConfig); err != nil {
+		return nil, err
+	}
+	if err = m.allocateIPs(spec, vmmConfig.Network.Interfaces); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && spec.restore == nil {
+			m.releaseIPs(vmmConfig.Network.Interfaces)
+		}
+	}()
+
+	var nameserver vmmpkg.Nameserver
+	if m.dns != nil {
+		nameserver = m.dns
+	}
+	props := actor.PropsFromProducer(func() actor.Actor { return vmm.NewVMMActor(m.logger, vmmConfig, nameserver) })
 	pid := m.rootContext.SpawnPrefix(props, "vmm/")
 
 	timeout := 30 * time.Second
@@ -78,11 +326,20 @@ func (m *VMMManager) StartVMM() (*vmm.Metadata, error) {
 
 	switch msg := startResult.(type) {
 	case *vmm.Started:
-		if err := m.db.add(msg.ID, pid, msg.IP); err != nil {
+		bootSpan.SetAttributes(attribute.String("firebox.vmid", msg.ID), attribute.String("firebox.ip", msg.IP.String()))
+		if err := m.db.add(msg.ID, pid, msg.IP, msg.Interfaces, spec, labels, volumes, created, bootSpan); err != nil {
 			// should never happen, otherwise the vmm should be stopped
 			return nil, err
 		}
-		return &msg.Metadata, nil
+		for _, name := range volumes {
+			m.stores.Volumes.Bind(name, msg.ID)
+		}
+		m.bindIPs(msg.Interfaces, msg.ID)
+		m.updateVMMetrics()
+		time.AfterFunc(bootTimeout, func() {
+			m.rootContext.Send(m.self, &bootExpired{vmid: msg.ID})
+		})
+		return &VM{Metadata: msg.Metadata, Service: spec.Service, Labels: labels}, nil
 
 	case *vmm.Failure:
 		return nil, msg.Err
@@ -91,6 +348,67 @@ func (m *VMMManager) StartVMM() (*vmm.Metadata, error) {
 	}
 }
 
+// dataDrives resolves the paths of the data disks among the drives, relative paths are relative to the data dir.
+// A data disk must be a regular file in the data dir after following the symlinks. The size of the scratch disks
+// is checked.
+func (m *VMMManager) dataDrives(drives []config.DriveConfig) ([]config.DriveConfig, error) {
+	result := append([]config.DriveConfig{}, drives...)
+	for i := range result {
+		if result[i].Path == "" && result[i].Volume == "" {
+			if err := volume.ValidateSize(result[i].SizeMib, m.vmmConfig.MaxDiskSizeMib); err != nil {
+				return nil, err
+			}
+		}
+		if result[i].Path == "" {
+			continue
+		}
+		dataDir, err := filepath.EvalSymlinks(m.vmmConfig.DataDir)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidDrive, "data dir %s: %v", m.vmmConfig.DataDir, err)
+		}
+		path := result[i].Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dataDir, path)
+		}
+		if path, err = filepath.EvalSymlinks(path); err != nil {
+			return nil, errors.Wrapf(ErrInvalidDrive, "'%s' not found", result[i].Path)
+		}
+		if rel, err := filepath.Rel(dataDir, path); err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			return nil, errors.Wrapf(ErrInvalidDrive, "'%s' is outside of the data dir", result[i].Path)
+		}
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			return nil, errors.Wrapf(ErrInvalidDrive, "'%s' is not a regular file", result[i].Path)
+		}
+		result[i].Path = path
+	}
+	return result, nil
+}
+
+// reserveVolumes resolves the paths of the persistent volumes among the drives,
+// the volumes stay reserved until they are released
+func (m *VMMManager) reserveVolumes(drives []config.DriveConfig) ([]string, error) {
+	var reserved []string
+	for i := range drives {
+		if drives[i].Volume == "" {
+			continue
+		}
+		v, err := m.stores.Volumes.Reserve(drives[i].Volume)
+		if err != nil {
+			m.releaseVolumes(reserved)
+			return nil, err
+		}
+		reserved = append(reserved, v.Name)
+		drives[i].Path = v.Path
+	}
+	return reserved, nil
+}
+
+func (m *VMMManager) releaseVolumes(volumes []string) {
+	for _, name := range volumes {
+		m.stores.Volumes.Release(name)
+	}
+}
+
 func (m *VMMManager) Close() error {
 	m.logger.Info("Stopping all VMMs")
 
@@ -103,42 +421,299 @@ func (m *VMMManager) Close() error {
 		if err != nil {
 			m.logger.Infof("Failed to stop vmid %v: %v", entry.vmid, err)
 		}
-		m.db.del(entry.vmid)
+		m.remove(entry.vmid)
+	}
+	if m.dns != nil {
+		_ = m.dns.Close()
+	}
+	return nil
+}
+
+// GetVM returns the running machine
+func (m *VMMManager) GetVM(vmid string) (*VM, error) {
+	e := m.db.entry(vmid)
+	if e == nil {
+		return nil, ErrVMNotFound
+	}
+	network := Condition{Type: ConditionNetworkReady, Status: e.networkErr == nil, LastTransition: e.networkChanged}
+	if e.networkErr != nil {
+		network.Message = e.networkErr.Error()
+	}
+	return &VM{
+		Metadata: vmm.Metadata{ID: e.vmid, IP: e.ip, Interfaces: e.interfaces},
+		Service:  e.service,
+		Labels:   e.labels,
+		Conditions: []Condition{
+			{Type: ConditionReady, Status: e.ready, LastTransition: e.readyChanged},
+			network,
+		},
+	}, nil
+}
+
+func (m *VMMManager) MachineMetrics(vmid string) (vmmpkg.Metrics, error) {
+	result, err := m.requestVM(vmid, &vmm.GetMetrics{})
+	if err != nil {
+		return nil, err
+	}
+	switch msg := result.(type) {
+	case *vmm.MachineMetrics:
+		return msg.Metrics, nil
+	default:
+		return nil, errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+func (m *VMMManager) Console(vmid string) (*console.Console, error) {
+	result, err := m.requestVM(vmid, &vmm.GetConsole{})
+	if err != nil {
+		return nil, err
+	}
+	switch msg := result.(type) {
+	case *vmm.MachineConsole:
+		return msg.Console, nil
+	default:
+		return nil, errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+// Snapshot stores a snapshot of the running machine under the given name
+func (m *VMMManager) Snapshot(vmid, name string) (*snapshot.Snapshot, error) {
+	entry := m.db.entry(vmid)
+	if entry == nil {
+		return nil, ErrVMNotFound
+	}
+	return m.stores.Snapshots.Create(name, vmid, entry.service, m.takeSnapshot(*entry))
+}
+
+// takeSnapshot returns the function writing the snapshot of the machine, the snapshot records the snapshot
+// the machine was started from
+func (m *VMMManager) takeSnapshot(e entry) func(dir string) (config.RestoreConfig, error) {
+	return func(dir string) (config.RestoreConfig, error) {
+		// the machine memory is written to disk, which takes longer than the other requests
+		result, err := m.rootContext.RequestFuture(e.pid, &vmm.CreateSnapshot{Dir: dir}, 2*time.Minute).Result()
+		if err != nil {
+			return config.RestoreConfig{}, err
+		}
+		switch msg := result.(type) {
+		case *vmm.SnapshotCreated:
+			msg.Restore.Source = e.spec.Snapshot
+			return msg.Restore, nil
+		case *vmm.Failure:
+			return config.RestoreConfig{}, msg.Err
+		default:
+			return config.RestoreConfig{}, errors.Errorf("Internal error: unexpected message: %v", msg)
+		}
+	}
+}
+
+// Pause freezes the machine, it does not serve invocations until it is resumed
+func (m *VMMManager) Pause(vmid string) error {
+	result, err := m.requestVM(vmid, &vmm.Pause{})
+	if err != nil {
+		return err
+	}
+	switch msg := result.(type) {
+	case *vmm.Paused:
+		m.logger.Infof("Machine PAUSED vmid: %v", msg.ID)
+		m.db.pause(vmid, true)
+		m.updateVMMetrics()
+		return nil
+	case *vmm.Failure:
+		return msg.Err
+	default:
+		return errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+func (m *VMMManager) Resume(vmid string) error {
+	result, err := m.requestVM(vmid, &vmm.Resume{})
+	if err != nil {
+		return err
+	}
+	switch msg := result.(type) {
+	case *vmm.Resumed:
+		m.logger.Infof("Machine RESUMED vmid: %v", msg.ID)
+		m.db.pause(vmid, false)
+		m.updateVMMetrics()
+		return nil
+	case *vmm.Failure:
+		return msg.Err
+	default:
+		return errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+// Balloon returns the balloon size and the guest memory statistics of the machine
+func (m *VMMManager) Balloon(vmid string) (vmmpkg.BalloonStats, error) {
+	result, err := m.requestVM(vmid, &vmm.GetBalloon{})
+	if err != nil {
+		return vmmpkg.BalloonStats{}, err
 	}
+	switch msg := result.(type) {
+	case *vmm.MachineBalloon:
+		return msg.Stats, nil
+	case *vmm.Failure:
+		return vmmpkg.BalloonStats{}, msg.Err
+	default:
+		return vmmpkg.BalloonStats{}, errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+// SetBalloon inflates or deflates the balloon of the machine, the size is kept until the next automatic reclaim
+func (m *VMMManager) SetBalloon(vmid string, amountMib int64) error {
+	if err := m.setBalloon(vmid, amountMib); err != nil {
+		return err
+	}
+	m.db.unreclaim(vmid)
 	return nil
 }
 
-func (m *VMMManager) InvokeHTTP(request *models.HTTPRequest) (*models.HTTPResponse, error) {
-	ip, err := m.getServiceIP()
+func (m *VMMManager) setBalloon(vmid string, amountMib int64) error {
+	result, err := m.requestVM(vmid, &vmm.SetBalloon{AmountMib: amountMib})
+	if err != nil {
+		return err
+	}
+	switch msg := result.(type) {
+	case *vmm.BalloonSet:
+		m.logger.Infof("Machine BALLOON vmid: %v, amount: %v Mib", msg.ID, amountMib)
+		return nil
+	case *vmm.Failure:
+		return msg.Err
+	default:
+		return errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+// Profiles returns the boot profiles by name
+func (m *VMMManager) Profiles() map[string]config.BootProfile {
+	return m.vmmConfig.Profiles
+}
+
+// SetMetadata replaces the metadata JSON of the machine in the MMDS
+func (m *VMMManager) SetMetadata(vmid string, data interface{}) error {
+	result, err := m.requestVM(vmid, &vmm.SetMetadata{Data: data})
+	if err != nil {
+		return err
+	}
+	switch msg := result.(type) {
+	case *vmm.MetadataSet:
+		m.logger.Infof("Machine METADATA updated vmid: %v", msg.ID)
+		return nil
+	case *vmm.Failure:
+		return msg.Err
+	default:
+		return errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+func (m *VMMManager) requestVM(vmid string, message interface{}) (interface{}, error) {
+	entry := m.db.entry(vmid)
+	if entry == nil {
+		return nil, ErrVMNotFound
+	}
+	return m.rootContext.RequestFuture(entry.pid, message, 5*time.Second).Result()
+}
+
+func (m *VMMManager) InvokeHTTP(ctx context.Context, service string, request *models.HTTPRequest) (*models.HTTPResponse, error) {
+	metrics.InvokeQueueDepth.Inc()
+	defer metrics.InvokeQueueDepth.Dec()
+
+	start := time.Now()
+	resp, err := m.invokeHTTP(ctx, service, request)
+	var statusCode int64
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	metrics.ObserveInvoke(m.metricsService(service), statusCode, err, time.Since(start))
+	return resp, err
+}
+
+// metricsService returns the service label of the invocation metrics, the services which are not configured
+// share a single label so that clients can not create series by naming services
+func (m *VMMManager) metricsService(service string) string {
+	if config.KnownService(&m.vmmConfig, service) {
+		return service
+	}
+	return metrics.ServiceUnknown
+}
+
+func (m *VMMManager) invokeHTTP(ctx context.Context, service string, request *models.HTTPRequest) (*models.HTTPResponse, error) {
+	e, err := m.queue(ctx, service)
 	if err != nil {
 		return nil, err
 	}
-	return m.invokeService(ip, request)
+	// the machine is not hibernated while it serves the invocation
+	defer m.db.done(e.vmid)
+	return m.invokeService(ctx, e.ip, request)
 }
 
-func (m *VMMManager) getServiceIP() (net.IP, error) {
+// queue waits until a machine of the service is available and picks it to serve the invocation
+func (m *VMMManager) queue(ctx context.Context, service string) (e *entry, err error) {
+	ctx, span := tracer.Start(ctx, "queue")
+	defer func() {
+		tracing.End(span, err)
+	}()
+	e, err = m.getService(ctx, service)
+	if err == nil {
+		return e, nil
+	}
+	restored, ok, rerr := m.restoreHibernated(ctx, service)
+	if !ok {
+		return nil, err
+	}
+	span.AddEvent(events.Restored)
+	return restored, rerr
+}
+
+func (m *VMMManager) getService(ctx context.Context, service string) (e *entry, err error) {
+	_, span := tracer.Start(ctx, "getServiceIP")
+	defer func() {
+		if e != nil {
+			span.SetAttributes(attribute.String("firebox.ip", e.ip.String()))
+		}
+		tracing.End(span, err)
+	}()
+
+	return m.pickService(service)
+}
+
+// pickService picks a ready machine of the service to serve an invocation, the invocation must be marked done
+func (m *VMMManager) pickService(service string) (*entry, error) {
 	// naive random LB
 
 	ready := make([]entry, 0)
 	for _, r := range m.db.entries() {
-		if r.ready {
+		if r.ready && !r.paused && !r.hibernating && r.service == service {
 			ready = append(ready, r)
 		}
 	}
-	l := len(ready)
-
-	if l == 0 {
-		return nil, errors.New("No READY machine found")
+	for _, r := range rand.Perm(len(ready)) {
+		// the machine could have started hibernating in the meantime
+		if m.db.use(ready[r].vmid) {
+			if m.db.unreclaim(ready[r].vmid) {
+				m.deflate(ready[r])
+			}
+			return &ready[r], nil
+		}
 	}
-	r := rand.Intn(l)
-	return ready[r].ip, nil
+	return nil, errors.Errorf("No READY machine found for service '%s'", service)
 }
 
-func (m *VMMManager) invokeService(ip net.IP, req *models.HTTPRequest) (*models.HTTPResponse, error) {
-	httpRequest, err := toHttpRequest(context.Background(), "http", ip.String(), 8080, req)
+func (m *VMMManager) invokeService(ctx context.Context, ip net.IP, req *models.HTTPRequest) (result *models.HTTPResponse, err error) {
+	ctx, span := tracer.Start(ctx, "invokeService", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int64("http.status_code", result.StatusCode))
+		}
+		tracing.End(span, err)
+	}()
+
+	httpRequest, err := toHttpRequest(ctx, "http", ip.String(), 8080, req)
 	if err != nil {
 		return nil, err
 	}
+	tracing.Inject(ctx, propagation.HeaderCarrier(httpRequest.Header))
+	span.SetAttributes(attribute.String("http.method", httpRequest.Method), attribute.String("http.url", httpRequest.URL.String()))
 	m.logger.Infof("Sending HTTP request to %s: %s %s", httpRequest.Host, httpRequest.Method, httpRequest.URL)
 
 	resp, err := http.DefaultClient.Do(httpRequest)
@@ -206,7 +781,7 @@ func toHttpRequest(ctx context.Context, schema string, ip string, port int, req
 	}
 	u := url.URL{
 		Scheme:   schema,
-		Host:     fmt.Sprintf("%s:%d", ip, port),
+		Host:     net.JoinHostPort(ip, strconv.Itoa(port)),
 		RawQuery: req.RawQueryString,
 		Path:     req.RawPath,
 	}
@@ -0,0 +1,223 @@
+package manager
+
+import (
+	"net"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/network"
+	"github.com/combust-labs/firebox/pkg/snapshot"
+	vmmpkg "github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/containernetworking/cni/libcni"
+	"github.com/pkg/errors"
+)
+
+// initLeases releases the guest IPs leased by the exited firebox processes except the IPs of the hibernated machines,
+// the leases of the running firebox processes sharing the bridges are kept
+func (m *VMMManager) initLeases() {
+	if len(m.stores.Bridges) == 0 {
+		return
+	}
+	hibernated := make(map[string]bool)
+	snapshots, err := m.stores.Hibernated.List()
+	if err != nil {
+		m.logger.Errorf("Loading hibernated machines failed: %v", err)
+	}
+	for _, s := range snapshots {
+		hibernated[s.VMID] = true
+	}
+	for name, ipam := range m.stores.Bridges {
+		pruned, err := ipam.Prune(func(lease network.Lease) bool {
+			return hibernated[lease.VMID]
+		})
+		if err != nil {
+			m.logger.Errorf("Pruning IP leases of bridge %v failed: %v", name, err)
+		}
+		for _, lease := range pruned {
+			m.logger.Infof("Released IP %v on bridge %v leased by vmid %v of the exited process %v", lease.IP, name, lease.VMID, lease.PID)
+		}
+	}
+}
+
+// interfaces returns the network interfaces of a machine: the interfaces of the snapshot for a restored machine,
+// otherwise the interfaces of the spec, of the service or the default interface
+func (m *VMMManager) interfaces(spec VMSpec, svc config.ServiceConfig, c *config.VMMConfig) ([]config.InterfaceConfig, error) {
+	if c.Restore != nil {
+		if len(spec.Interfaces) > 0 {
+			return nil, ErrInterfacesWithSnapshot
+		}
+		return config.RestoreInterfaces(c), nil
+	}
+	var result []config.InterfaceConfig
+	switch {
+	case len(spec.Interfaces) > 0:
+		result = append(result, spec.Interfaces...)
+	case len(svc.Interfaces) > 0:
+		result = append(result, svc.Interfaces...)
+	default:
+		result = append(result, config.DefaultInterface(c))
+	}
+	for _, iface := range result {
+		if iface.AllowMMDS && !c.Network.AllowMMDS {
+			return nil, vmmpkg.ErrMMDSDisabled
+		}
+		if err := validateCapabilityArgs(c, iface); err != nil {
+			return nil, errors.Wrapf(ErrInvalidInterface, "'%s': %v", iface.Network, err)
+		}
+		if c.Network.CNI.Enable {
+			if _, err := libcni.LoadConfList(c.Network.CNI.ConfDir, iface.Network); err != nil {
+				return nil, errors.Wrapf(ErrNetworkNotFound, "'%s': %v", iface.Network, err)
+			}
+			continue
+		}
+		if _, ok := m.stores.Bridges[iface.Network]; !ok {
+			return nil, errors.Wrapf(ErrNetworkNotFound, "'%s'", iface.Network)
+		}
+	}
+	return result, nil
+}
+
+// validateCapabilityArgs checks the CNI capability args of the interface, they require CNI
+func validateCapabilityArgs(c *config.VMMConfig, iface config.InterfaceConfig) error {
+	if !c.Network.CNI.Enable && (len(iface.PortMappings) > 0 || iface.Bandwidth != nil || len(iface.IPs) > 0 || iface.MAC != "") {
+		return errors.New("port mappings, bandwidth, ips and mac require CNI")
+	}
+	for _, pm := range iface.PortMappings {
+		if pm.HostPort < 1 || pm.HostPort > 65535 || pm.GuestPort < 1 || pm.GuestPort > 65535 {
+			return errors.Errorf("invalid port mapping %d:%d", pm.HostPort, pm.GuestPort)
+		}
+		switch pm.Protocol {
+		case "", "tcp", "udp", "sctp":
+		default:
+			return errors.Errorf("invalid port mapping protocol '%s'", pm.Protocol)
+		}
+		if pm.HostIP != "" && net.ParseIP(pm.HostIP) == nil {
+			return errors.Errorf("invalid port mapping host IP '%s'", pm.HostIP)
+		}
+	}
+	if b := iface.Bandwidth; b != nil && (b.IngressRate < 0 || b.IngressBurst < 0 || b.EgressRate < 0 || b.EgressBurst < 0) {
+		return errors.New("bandwidth rates and bursts must not be negative")
+	}
+	if b := iface.Bandwidth; b != nil && ((b.IngressRate > 0) != (b.IngressBurst > 0) || (b.EgressRate > 0) != (b.EgressBurst > 0)) {
+		return errors.New("bandwidth rate and burst must be set together")
+	}
+	for _, ip := range iface.IPs {
+		if _, _, err := net.ParseCIDR(ip); err != nil {
+			return errors.Errorf("invalid IP '%s', CIDR notation expected", ip)
+		}
+	}
+	if iface.MAC != "" {
+		if _, err := net.ParseMAC(iface.MAC); err != nil {
+			return errors.Errorf("invalid MAC '%s'", iface.MAC)
+		}
+	}
+	return nil
+}
+
+// allocateIPs leases the guest IPs of the interfaces on the bridges of the built-in network. A machine restored from
+// a snapshot gets the IPs of the snapshot, the IPs of a hibernated machine stay leased while it hibernates.
+// The leased IPs are released when the allocation fails.
+func (m *VMMManager) allocateIPs(spec VMSpec, interfaces []config.InterfaceConfig) error {
+	if len(m.stores.Bridges) == 0 {
+		return nil
+	}
+	for i := range interfaces {
+		ip, err := m.allocateIP(spec, interfaces[i])
+		if err != nil {
+			if spec.restore == nil {
+				m.releaseIPs(interfaces[:i])
+			}
+			return errors.Wrapf(err, "network interface %d on '%s'", i, interfaces[i].Network)
+		}
+		interfaces[i].IP = ip.String()
+	}
+	return nil
+}
+
+func (m *VMMManager) allocateIP(spec VMSpec, iface config.InterfaceConfig) (net.IP, error) {
+	ipam := m.stores.Bridges[iface.Network]
+	if ipam == nil {
+		return nil, errors.Wrapf(ErrNetworkNotFound, "'%s'", iface.Network)
+	}
+	if iface.IP == "" {
+		return ipam.Allocate()
+	}
+	ip := net.ParseIP(iface.IP)
+	if ip == nil {
+		return nil, errors.Errorf("invalid guest IP '%s'", iface.IP)
+	}
+	if spec.restore != nil {
+		return ip, nil
+	}
+	if err := ipam.Reserve(ip); err != nil {
+		return nil, errors.Wrap(err, "the guest IP is not available")
+	}
+	return ip, nil
+}
+
+func (m *VMMManager) bindIPs(interfaces []vmmpkg.Interface, vmid string) {
+	for _, iface := range interfaces {
+		ipam := m.stores.Bridges[iface.Network]
+		if ipam == nil || iface.IP.IP == nil {
+			continue
+		}
+		if err := ipam.Bind(iface.IP.IP, vmid); err != nil {
+			m.logger.Errorf("Binding IP %v to vmid %v failed: %v", iface.IP.IP, vmid, err)
+		}
+	}
+}
+
+// releaseIPs releases the IPs leased for a machine which failed to start
+func (m *VMMManager) releaseIPs(interfaces []config.InterfaceConfig) {
+	for _, iface := range interfaces {
+		m.releaseIP(iface.Network, net.ParseIP(iface.IP), "")
+	}
+}
+
+func (m *VMMManager) releaseIP(bridge string, ip net.IP, vmid string) {
+	ipam := m.stores.Bridges[bridge]
+	if ipam == nil || ip == nil {
+		return
+	}
+	if err := ipam.Release(ip, vmid); err != nil {
+		m.logger.Errorf("Releasing IP %v on bridge %v failed: %v", ip, bridge, err)
+	}
+}
+
+// reserveHibernatedIPs keeps the IPs of a machine hibernated by a previous run leased until it is restored
+func (m *VMMManager) reserveHibernatedIPs(s *snapshot.Snapshot) error {
+	if len(m.stores.Bridges) == 0 {
+		return nil
+	}
+	restore := s.RestoreConfig()
+	interfaces := config.RestoreInterfaces(&config.VMMConfig{Network: m.vmmConfig.Network, Restore: restore})
+	for _, iface := range interfaces {
+		if err := m.reserveHibernatedIP(s.VMID, iface); err != nil {
+			return errors.Wrapf(err, "bridge '%s'", iface.Network)
+		}
+	}
+	return nil
+}
+
+func (m *VMMManager) reserveHibernatedIP(vmid string, iface config.InterfaceConfig) error {
+	ipam := m.stores.Bridges[iface.Network]
+	if ipam == nil {
+		return ErrNetworkNotFound
+	}
+	ip := net.ParseIP(iface.IP)
+	if ip == nil {
+		return errors.Errorf("invalid guest IP '%s'", iface.IP)
+	}
+	leases, err := ipam.Leases()
+	if err != nil {
+		return err
+	}
+	for _, lease := range leases {
+		if lease.IP == ip.String() && lease.VMID == vmid {
+			return nil
+		}
+	}
+	if err := ipam.Reserve(ip); err != nil {
+		return err
+	}
+	return ipam.Bind(ip, vmid)
+}
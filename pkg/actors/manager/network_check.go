@@ -0,0 +1,132 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/actors/ticker"
+	"github.com/combust-labs/firebox/pkg/actors/vmm"
+	"github.com/combust-labs/firebox/pkg/events"
+	vmmpkg "github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/pkg/errors"
+)
+
+// initNetworkCheck starts the periodic check of the network attachments of the machines
+func (m *VMMManager) initNetworkCheck() {
+	cfg := m.vmmConfig.NetworkCheck
+	if cfg.Interval <= 0 {
+		return
+	}
+	switch cfg.Repair {
+	case config.NetworkRepairNone, config.NetworkRepairReattach, config.NetworkRepairReplace:
+	default:
+		m.logger.Warnf("Network repair disabled: unknown repair '%s'", cfg.Repair)
+		m.vmmConfig.NetworkCheck.Repair = config.NetworkRepairNone
+	}
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return ticker.NewTickerActor(cfg.Interval, m.checkNetworks)
+	})
+	pid := m.rootContext.SpawnPrefix(props, "vmm-manager/network-check/")
+	m.rootContext.Send(pid, &ticker.Start{})
+}
+
+// checkNetworks checks the network attachments of the machines and repairs the failing ones
+func (m *VMMManager) checkNetworks() {
+	for _, e := range m.db.entries() {
+		if e.hibernating {
+			continue
+		}
+		err := m.checkNetwork(e)
+		if changed := m.db.network(e.vmid, err); changed {
+			m.recordNetworkCheck(e, err)
+		}
+		if err == nil {
+			continue
+		}
+		if err := m.repairNetwork(e, err); err != nil {
+			m.logger.Errorf("Network repair of vmid %s failed: %v", e.vmid, err)
+		}
+	}
+}
+
+func (m *VMMManager) checkNetwork(e entry) error {
+	result, err := m.rootContext.RequestFuture(e.pid, &vmm.CheckNetwork{}, time.Minute).Result()
+	if err != nil {
+		return errors.Wrap(err, "network check request failed")
+	}
+	switch msg := result.(type) {
+	case *vmm.NetworkChecked:
+		return msg.Err
+	default:
+		return errors.Errorf("Internal error: unexpected message: %v", msg)
+	}
+}
+
+func (m *VMMManager) recordNetworkCheck(e entry, err error) {
+	if err == nil {
+		m.logger.Infof("Machine NETWORK READY vmid: %v", e.vmid)
+		m.events.Record(events.Event{Type: events.NetworkRecovered, VMID: e.vmid, Service: e.service})
+		return
+	}
+	m.logger.Warnf("Machine NETWORK UNREADY vmid: %v: %v", e.vmid, err)
+	m.events.Record(events.Event{Type: events.NetworkFailed, VMID: e.vmid, Service: e.service, Message: err.Error()})
+}
+
+// repairNetwork attaches the interfaces of the machine again or replaces the machine according to the repair config,
+// the machines on CNI are replaced since their interfaces can not be attached again
+func (m *VMMManager) repairNetwork(e entry, checkErr error) error {
+	switch m.vmmConfig.NetworkCheck.Repair {
+	case config.NetworkRepairReattach:
+		result, err := m.requestVM(e.vmid, &vmm.RepairNetwork{})
+		if failure, ok := result.(*vmm.Failure); ok {
+			err = failure.Err
+		}
+		if errors.Is(err, vmmpkg.ErrRepairUnsupported) {
+			return m.replace(e, checkErr)
+		}
+		if err != nil {
+			return err
+		}
+		err = m.checkNetwork(e)
+		if m.db.network(e.vmid, err) {
+			m.recordNetworkCheck(e, err)
+		}
+		if err != nil {
+			return errors.Wrap(err, "network check after the repair failed")
+		}
+		m.logger.Infof("Machine NETWORK REPAIRED vmid: %v", e.vmid)
+		m.events.Record(events.Event{Type: events.NetworkRepaired, VMID: e.vmid, Service: e.service})
+		return nil
+	case config.NetworkRepairReplace:
+		return m.replace(e, checkErr)
+	default:
+		return nil
+	}
+}
+
+// replace stops the machine and starts a new machine with the spec of the stopped one
+func (m *VMMManager) replace(e entry, reason error) error {
+	if _, err := m.rootContext.RequestFuture(e.pid, &vmm.Stop{}, m.vmmConfig.VMM.ShutdownTimeout+5*time.Second).Result(); err != nil {
+		m.logger.Warnf("Failed to stop replaced vmid %v: %v", e.vmid, err)
+	}
+	m.remove(e.vmid)
+	spec := e.spec
+	// the snapshot of a hibernated machine is deleted once it is restored, the replacement of a restored
+	// hibernated machine is restored from the snapshot the hibernated machine was started from or booted
+	spec.restore = nil
+	machine, err := m.StartVMM(context.Background(), spec)
+	if err != nil {
+		return errors.Wrapf(err, "starting the replacement of vmid %s failed", e.vmid)
+	}
+	m.logger.Infof("Machine REPLACED vmid: %v, replacement vmid: %v", e.vmid, machine.ID)
+	m.events.Record(events.Event{
+		Type:    events.Replaced,
+		VMID:    e.vmid,
+		Service: e.service,
+		Message: fmt.Sprintf("replaced by %s: %v", machine.ID, reason),
+	})
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/combust-labs/firebox/pkg/actors/ticker"
 	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/combust-labs/firebox/pkg/metrics"
 	httpprober "github.com/combust-labs/firebox/pkg/prober/remote/http"
 	"net"
 	"net/http"
@@ -37,8 +38,10 @@ func TickerFunc(logger *log.Logger, spec ProbeSpec, context actor.Context, readi
 		err := prober.Probe(probeUrl, headers, time.Duration(spec.TimeoutSeconds)*time.Second)
 		logger.Debugf("Probe result err %v", err)
 		if err != nil {
+			metrics.ProbeResults.WithLabelValues(metrics.ProbeFailure).Inc()
 			context.Send(readinessPID, &Unready{Metadata: metadata})
 		} else {
+			metrics.ProbeResults.WithLabelValues(metrics.ProbeSuccess).Inc()
 			context.Send(readinessPID, &Ready{Metadata: metadata})
 		}
 	}
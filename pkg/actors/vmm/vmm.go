@@ -4,8 +4,10 @@ import (
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/combust-labs/firebox/config"
 	"github.com/combust-labs/firebox/pkg/actors/ticker"
+	"github.com/combust-labs/firebox/pkg/console"
 	"github.com/combust-labs/firebox/pkg/log"
 	"github.com/combust-labs/firebox/pkg/vmm"
+	"github.com/pkg/errors"
 	"net"
 	"time"
 )
@@ -13,6 +15,8 @@ import (
 type Metadata struct {
 	ID string
 	IP net.IP
+	// Interfaces of the machine, IP is the guest IP of the first one
+	Interfaces []vmm.Interface
 }
 
 type Start struct {
@@ -31,6 +35,75 @@ type Failure struct {
 	Err error
 }
 
+type GetMetrics struct{}
+type MachineMetrics struct {
+	Metrics vmm.Metrics
+}
+
+type GetConsole struct{}
+type MachineConsole struct {
+	Console *console.Console
+}
+
+type CreateSnapshot struct {
+	Dir string
+}
+type SnapshotCreated struct {
+	Restore config.RestoreConfig
+}
+
+type Pause struct{}
+type Paused struct {
+	ID string
+}
+
+type Resume struct{}
+type Resumed struct {
+	ID string
+}
+
+type GetBalloon struct{}
+type MachineBalloon struct {
+	Stats vmm.BalloonStats
+}
+
+type SetBalloon struct {
+	AmountMib int64
+}
+type BalloonSet struct {
+	ID string
+}
+
+type SetMetadata struct {
+	Data interface{}
+}
+type MetadataSet struct {
+	ID string
+}
+
+type SetEnv struct {
+	Vars map[string]string
+}
+type EnvSet struct {
+	ID string
+}
+
+type CheckNetwork struct{}
+type NetworkChecked struct {
+	// Err is nil when the network attachments are healthy
+	Err error
+}
+
+type RepairNetwork struct{}
+type NetworkRepaired struct {
+	ID string
+}
+
+var (
+	ErrPaused    = errors.New("VM is paused")
+	ErrNotPaused = errors.New("VM is not paused")
+)
+
 // internal message
 type finished struct {
 	err error
@@ -43,13 +116,15 @@ type VMMActor struct {
 	machine vmm.VMM
 
 	manager *actor.PID
+	// health probes are suspended while the machine is paused
+	healthPID *actor.PID
 }
 
-func NewVMMActor(logger *log.Logger, vmmConfig config.VMMConfig) actor.Actor {
+func NewVMMActor(logger *log.Logger, vmmConfig config.VMMConfig, nameserver vmm.Nameserver) actor.Actor {
 	act := &VMMActor{
 		behavior: actor.NewBehavior(),
 		logger:   logger,
-		machine:  vmm.NewVMM(logger, vmmConfig),
+		machine:  vmm.NewVMM(logger, vmmConfig, nameserver),
 	}
 	act.behavior.Become(act.Stopped)
 	return act
@@ -76,10 +151,45 @@ func (a *VMMActor) Stopped(context actor.Context) {
 }
 
 func (a *VMMActor) metadata() Metadata {
-	return Metadata{ID: a.machine.GetID(), IP: a.machine.GetIP()}
+	return Metadata{ID: a.machine.GetID(), IP: a.machine.GetIP(), Interfaces: a.machine.Interfaces()}
 }
 
 func (a *VMMActor) Started(context actor.Context) {
+	switch context.Message().(type) {
+	case *Pause:
+		if err := a.machine.Pause(); err != nil {
+			context.Respond(&Failure{Err: err})
+			return
+		}
+		context.Send(a.healthPID, &ticker.Stop{})
+		a.behavior.Become(a.Paused)
+		context.Respond(&Paused{ID: a.machine.GetID()})
+	case *Resume:
+		context.Respond(&Failure{Err: ErrNotPaused})
+	default:
+		a.running(context)
+	}
+}
+
+func (a *VMMActor) Paused(context actor.Context) {
+	switch context.Message().(type) {
+	case *Pause:
+		context.Respond(&Failure{Err: ErrPaused})
+	case *Resume:
+		if err := a.machine.Resume(); err != nil {
+			context.Respond(&Failure{Err: err})
+			return
+		}
+		context.Send(a.healthPID, &ticker.Start{})
+		a.behavior.Become(a.Started)
+		context.Respond(&Resumed{ID: a.machine.GetID()})
+	default:
+		a.running(context)
+	}
+}
+
+// running handles the messages of the started and of the paused machine alike
+func (a *VMMActor) running(context actor.Context) {
 	switch msg := context.Message().(type) {
 	case *actor.Stopping:
 		a.stopVMM()
@@ -88,12 +198,88 @@ func (a *VMMActor) Started(context actor.Context) {
 		a.stopVMM()
 		a.behavior.Become(a.Stopped)
 		context.Respond(&Stopped{ID: a.machine.GetID()})
+	case *GetMetrics:
+		context.Respond(&MachineMetrics{Metrics: a.machine.Metrics()})
+	case *GetConsole:
+		context.Respond(&MachineConsole{Console: a.machine.Console()})
+	case *CreateSnapshot:
+		a.createSnapshot(context, msg)
+	case *GetBalloon:
+		a.getBalloon(context)
+	case *SetBalloon:
+		a.setBalloon(context, msg)
+	case *SetMetadata:
+		a.setMetadata(context, msg)
+	case *SetEnv:
+		a.setEnv(context, msg)
+	case *CheckNetwork:
+		a.checkNetwork(context)
+	case *RepairNetwork:
+		a.repairNetwork(context)
 	case *finished:
-		a.logger.Warnf("VMM machine finished with error: %v", msg.err)
-		context.Send(a.manager, &Stopped{ID: a.machine.GetID()})
-		// lifecycle invokes *actor.Stopping
-		context.Stop(context.Self())
+		a.finished(context, msg)
+	}
+}
+
+func (a *VMMActor) createSnapshot(context actor.Context, msg *CreateSnapshot) {
+	restore, err := a.machine.Snapshot(msg.Dir)
+	if err != nil {
+		context.Respond(&Failure{Err: err})
+		return
+	}
+	context.Respond(&SnapshotCreated{Restore: restore})
+}
+
+func (a *VMMActor) getBalloon(context actor.Context) {
+	stats, err := a.machine.Balloon()
+	if err != nil {
+		context.Respond(&Failure{Err: err})
+		return
+	}
+	context.Respond(&MachineBalloon{Stats: stats})
+}
+
+func (a *VMMActor) setBalloon(context actor.Context, msg *SetBalloon) {
+	if err := a.machine.SetBalloon(msg.AmountMib); err != nil {
+		context.Respond(&Failure{Err: err})
+		return
+	}
+	context.Respond(&BalloonSet{ID: a.machine.GetID()})
+}
+
+func (a *VMMActor) setMetadata(context actor.Context, msg *SetMetadata) {
+	if err := a.machine.SetMetadata(msg.Data); err != nil {
+		context.Respond(&Failure{Err: err})
+		return
+	}
+	context.Respond(&MetadataSet{ID: a.machine.GetID()})
+}
+
+func (a *VMMActor) setEnv(context actor.Context, msg *SetEnv) {
+	if err := a.machine.SetEnv(msg.Vars); err != nil {
+		context.Respond(&Failure{Err: err})
+		return
 	}
+	context.Respond(&EnvSet{ID: a.machine.GetID()})
+}
+
+func (a *VMMActor) checkNetwork(context actor.Context) {
+	context.Respond(&NetworkChecked{Err: a.machine.CheckNetwork()})
+}
+
+func (a *VMMActor) repairNetwork(context actor.Context) {
+	if err := a.machine.RepairNetwork(); err != nil {
+		context.Respond(&Failure{Err: err})
+		return
+	}
+	context.Respond(&NetworkRepaired{ID: a.machine.GetID()})
+}
+
+func (a *VMMActor) finished(context actor.Context, msg *finished) {
+	a.logger.Warnf("VMM machine finished with error: %v", msg.err)
+	context.Send(a.manager, &Stopped{ID: a.machine.GetID()})
+	// lifecycle invokes *actor.Stopping
+	context.Stop(context.Self())
 }
 
 func (a *VMMActor) startVMM(context actor.Context, _ *Start) error {
@@ -116,7 +302,7 @@ func (a *VMMActor) stopVMM() {
 	a.logger.Infof("Stopping VMM")
 	err := a.machine.Stop()
 	if err != nil {
-		a.logger.Warn("VMM stop error: %v", err)
+		a.logger.Warnf("VMM stop error: %v", err)
 	}
 }
 
@@ -144,7 +330,7 @@ func (a *VMMActor) startHealthProbe(context actor.Context) {
 	props = actor.PropsFromProducer(func() actor.Actor {
 		return ticker.NewTickerActor(time.Duration(probeSpec.PeriodSeconds)*time.Second, TickerFunc(a.logger, probeSpec, context, readinessPID, a.metadata()))
 	})
-	healthPID := context.SpawnPrefix(props, "vmm/probe/")
+	a.healthPID = context.SpawnPrefix(props, "vmm/probe/")
 	// start the probe
-	context.Send(healthPID, &ticker.Start{})
+	context.Send(a.healthPID, &ticker.Start{})
 }
@@ -0,0 +1,117 @@
+package console
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Buffer keeps the last size bytes written to it and fans out new writes to its subscribers
+type Buffer struct {
+	mu          sync.Mutex
+	size        int
+	data        []byte
+	subscribers map[chan []byte]struct{}
+	closed      bool
+}
+
+func NewBuffer(size int) *Buffer {
+	return &Buffer{
+		size:        size,
+		subscribers: make(map[chan []byte]struct{}),
+	}
+}
+
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if len(b.data) > b.size {
+		b.data = b.data[len(b.data)-b.size:]
+	}
+	chunk := append([]byte(nil), p...)
+	for ch := range b.subscribers {
+		select {
+		case ch <- chunk:
+		default:
+			// slow subscriber, drop the chunk rather than blocking the console
+		}
+	}
+	return len(p), nil
+}
+
+// Tail returns the last n lines kept in the buffer, all of them if n is negative
+func (b *Buffer) Tail(n int) []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tail(n)
+}
+
+func (b *Buffer) tail(n int) []byte {
+	if n < 0 {
+		return append([]byte(nil), b.data...)
+	}
+	if n == 0 {
+		return nil
+	}
+	start := len(b.data)
+	// the trailing newline terminates the last line, it does not start a new one
+	if start > 0 && b.data[start-1] == '\n' {
+		start--
+	}
+	for lines := 1; start > 0; lines++ {
+		i := bytes.LastIndexByte(b.data[:start], '\n')
+		if lines == n || i < 0 {
+			start = i + 1
+			break
+		}
+		start = i
+	}
+	return append([]byte(nil), b.data[start:]...)
+}
+
+// Follow atomically returns the last n lines like Tail and subscribes to the data written afterwards
+func (b *Buffer) Follow(n int) ([]byte, <-chan []byte, func()) {
+	b.mu.Lock()
+	tail := b.tail(n)
+	ch, cancel := b.subscribe()
+	b.mu.Unlock()
+	return tail, ch, cancel
+}
+
+// Subscribe returns a channel receiving all data written after the call, the channel is closed
+// when the buffer is closed or the returned cancel function is called
+func (b *Buffer) Subscribe() (<-chan []byte, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe()
+}
+
+func (b *Buffer) subscribe() (<-chan []byte, func()) {
+	ch := make(chan []byte, 64)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *Buffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+	return nil
+}
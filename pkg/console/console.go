@@ -0,0 +1,73 @@
+package console
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/pkg/errors"
+)
+
+const LogFileName = "console.log"
+
+// Console captures the serial console output of a machine into a ring buffer and a rotated log file.
+// Input written to the console is delivered to the machine through the Stdin pipe.
+type Console struct {
+	*Buffer
+	file    *RotatingFile
+	writers []io.Writer
+
+	inputMu     sync.Mutex
+	stdinReader *os.File
+	stdinWriter *os.File
+}
+
+// New creates the console capturing into dir, output is additionally copied to the given writers
+func New(dir string, c config.ConsoleConfig, writers ...io.Writer) (*Console, error) {
+	file, err := NewRotatingFile(filepath.Join(dir, LogFileName), c.LogMaxSize, c.LogMaxFiles)
+	if err != nil {
+		return nil, err
+	}
+	stdinReader, stdinWriter, err := os.Pipe()
+	if err != nil {
+		_ = file.Close()
+		return nil, errors.Wrap(err, "creating stdin pipe failed")
+	}
+	buffer := NewBuffer(c.BufferSize)
+	return &Console{
+		Buffer:      buffer,
+		file:        file,
+		writers:     append([]io.Writer{buffer, file}, writers...),
+		stdinReader: stdinReader,
+		stdinWriter: stdinWriter,
+	}, nil
+}
+
+// Stdin is the reading end of the input pipe to be connected to the stdin of the machine
+func (c *Console) Stdin() *os.File {
+	return c.stdinReader
+}
+
+// Input sends p to the machine, writes of concurrent clients are not interleaved
+func (c *Console) Input(p []byte) (int, error) {
+	c.inputMu.Lock()
+	defer c.inputMu.Unlock()
+	return c.stdinWriter.Write(p)
+}
+
+// Write never fails, a failing writer must not block the console output of the machine
+func (c *Console) Write(p []byte) (int, error) {
+	for _, w := range c.writers {
+		_, _ = w.Write(p)
+	}
+	return len(p), nil
+}
+
+func (c *Console) Close() error {
+	_ = c.Buffer.Close()
+	_ = c.stdinWriter.Close()
+	_ = c.stdinReader.Close()
+	return c.file.Close()
+}
@@ -0,0 +1,404 @@
+// Package dns implements the DNS server of the guests. It resolves the names of the machines in the firebox domain
+// to their guest IPs and forwards the other queries to the upstream nameservers.
+package dns
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/log"
+	"github.com/pkg/errors"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// DefaultDomain of the names of the machines
+	DefaultDomain = "firebox"
+	// forwardTimeout limits a query forwarded to an upstream nameserver
+	forwardTimeout = 5 * time.Second
+	// tcpIdleTimeout closes the TCP connections of the clients without queries
+	tcpIdleTimeout = 10 * time.Second
+	// udpMessageSize is the size of the UDP answers, the larger answers are truncated
+	udpMessageSize = 512
+	maxMessageSize = 65535
+)
+
+// Resolver returns the guest IPs of the machines by the names of the firebox domain
+type Resolver interface {
+	// VM returns the IPs of the machine with the VMID
+	VM(vmid string) []net.IP
+	// Service returns the IPs of the ready machines of the service
+	Service(name string) []net.IP
+	// Label returns the IPs of the ready machines with the label
+	Label(key, value string) []net.IP
+}
+
+// Server answers the queries of the guests on the gateways of their subnets over UDP and TCP. The names
+// <vmid>.vm.<domain>, <service>.svc.<domain> and <value>.<key>.label.<domain> are resolved by the Resolver.
+type Server struct {
+	logger   *log.Logger
+	resolver Resolver
+	// domain is lower case with the trailing dot
+	domain   string
+	ttl      uint32
+	upstream []string
+
+	mu sync.Mutex
+	// listeners are the UDP connection and the TCP listener by IP
+	listeners map[string][]io.Closer
+}
+
+// ValidateConfig checks the domain and the upstream nameservers of the config
+func ValidateConfig(cfg config.DNSConfig) error {
+	if cfg.Domain != "" {
+		if _, err := dnsmessage.NewName(strings.TrimSuffix(cfg.Domain, ".") + "."); err != nil {
+			return errors.Wrapf(err, "invalid DNS domain '%s'", cfg.Domain)
+		}
+	}
+	for _, ns := range cfg.Upstream {
+		host, _, err := net.SplitHostPort(upstreamAddr(ns))
+		if err != nil || net.ParseIP(host) == nil {
+			return errors.Errorf("invalid upstream nameserver '%s', an IP with an optional port expected", ns)
+		}
+	}
+	return nil
+}
+
+// upstreamAddr adds the DNS port to the nameserver without port
+func upstreamAddr(ns string) string {
+	if _, _, err := net.SplitHostPort(ns); err != nil {
+		return net.JoinHostPort(ns, "53")
+	}
+	return ns
+}
+
+// NewServer returns the server of the config, the upstream nameservers default to the nameservers of the host
+func NewServer(logger *log.Logger, cfg config.DNSConfig, resolver Resolver) (*Server, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+	domain := cfg.Domain
+	if domain == "" {
+		domain = DefaultDomain
+	}
+	upstream := cfg.Upstream
+	if len(upstream) == 0 {
+		var err error
+		if upstream, err = hostNameservers("/etc/resolv.conf"); err != nil {
+			return nil, err
+		}
+	}
+	s := &Server{
+		logger:    logger,
+		resolver:  resolver,
+		domain:    strings.ToLower(strings.TrimSuffix(domain, ".")) + ".",
+		ttl:       uint32(cfg.TTL / time.Second),
+		listeners: make(map[string][]io.Closer),
+	}
+	for _, ns := range upstream {
+		s.upstream = append(s.upstream, upstreamAddr(ns))
+	}
+	return s, nil
+}
+
+// hostNameservers returns the nameservers of the resolv.conf file
+func hostNameservers(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s failed", file)
+	}
+	defer f.Close()
+	var result []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			result = append(result, fields[1])
+		}
+	}
+	return result, scanner.Err()
+}
+
+// Listen serves the clients in the subnet of the address on port 53 of its IP, the gateway of the guests.
+// Listening on the same IP again does nothing.
+func (s *Server) Listen(addr net.IPNet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := addr.IP.String()
+	if _, ok := s.listeners[key]; ok {
+		return nil
+	}
+	hostPort := net.JoinHostPort(key, "53")
+	conn, err := net.ListenPacket("udp", hostPort)
+	if err != nil {
+		return errors.Wrapf(err, "listening on %s failed", hostPort)
+	}
+	l, err := net.Listen("tcp", hostPort)
+	if err != nil {
+		_ = conn.Close()
+		return errors.Wrapf(err, "listening on %s failed", hostPort)
+	}
+	s.listeners[key] = []io.Closer{conn, l}
+	subnet := net.IPNet{IP: addr.IP.Mask(addr.Mask), Mask: addr.Mask}
+	go s.serveUDP(conn, subnet)
+	go s.serveTCP(l, subnet)
+	s.logger.Infof("DNS server listening on %s for %s", hostPort, subnet.String())
+	return nil
+}
+
+// Close stops listening on all IPs
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ip, closers := range s.listeners {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+		delete(s.listeners, ip)
+	}
+	return nil
+}
+
+func (s *Server) serveUDP(conn net.PacketConn, subnet net.IPNet) {
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			// the connection is closed
+			return
+		}
+		if !subnet.Contains(addr.(*net.UDPAddr).IP) {
+			continue
+		}
+		query := append([]byte{}, buf[:n]...)
+		go func() {
+			if answer := s.handle(query, "udp"); answer != nil {
+				_, _ = conn.WriteTo(answer, addr)
+			}
+		}()
+	}
+}
+
+func (s *Server) serveTCP(l net.Listener, subnet net.IPNet) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			// the listener is closed
+			return
+		}
+		if !subnet.Contains(conn.RemoteAddr().(*net.TCPAddr).IP) {
+			_ = conn.Close()
+			continue
+		}
+		go func() {
+			defer conn.Close()
+			for {
+				_ = conn.SetDeadline(time.Now().Add(tcpIdleTimeout))
+				query, err := readTCP(conn)
+				if err != nil {
+					return
+				}
+				answer := s.handle(query, "tcp")
+				if answer == nil || writeTCP(conn, answer) != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// readTCP reads a message prefixed by its length
+func readTCP(r io.Reader) ([]byte, error) {
+	var size uint16
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, size)
+	_, err := io.ReadFull(r, msg)
+	return msg, err
+}
+
+func writeTCP(w io.Writer, msg []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint16(len(msg))); err != nil {
+		return err
+	}
+	_, err := w.Write(msg)
+	return err
+}
+
+// handle returns the answer to the query received over the network, nil for the malformed queries
+func (s *Server) handle(query []byte, network string) []byte {
+	var p dnsmessage.Parser
+	header, err := p.Start(query)
+	if err != nil || header.Response {
+		return nil
+	}
+	q, err := p.Question()
+	if err != nil {
+		return reply(header, nil, dnsmessage.RCodeFormatError, false)
+	}
+	name := strings.ToLower(q.Name.String())
+	if name != s.domain && !strings.HasSuffix(name, "."+s.domain) {
+		answer, err := s.forward(query, network)
+		if err != nil {
+			s.logger.Debugf("Forwarding DNS query for %s failed: %v", name, err)
+			return reply(header, &q, dnsmessage.RCodeServerFailure, false)
+		}
+		return answer
+	}
+	if header.OpCode != 0 {
+		return reply(header, &q, dnsmessage.RCodeNotImplemented, false)
+	}
+	ips := s.resolve(strings.TrimSuffix(strings.TrimSuffix(name, s.domain), "."))
+	if len(ips) == 0 {
+		return reply(header, &q, dnsmessage.RCodeNameError, true)
+	}
+	answer, err := s.answer(header, q, ips, false)
+	if err == nil && network == "udp" && len(answer) > udpMessageSize {
+		// the client asks again over TCP
+		answer, err = s.answer(header, q, nil, true)
+	}
+	if err != nil {
+		s.logger.Errorf("Building DNS answer for %s failed: %v", name, err)
+		return reply(header, &q, dnsmessage.RCodeServerFailure, false)
+	}
+	return answer
+}
+
+// resolve returns the IPs of the name relative to the domain
+func (s *Server) resolve(name string) []net.IP {
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return nil
+	}
+	switch labels[len(labels)-1] {
+	case "vm":
+		if len(labels) == 2 {
+			return s.resolver.VM(labels[0])
+		}
+	case "svc":
+		if len(labels) == 2 {
+			return s.resolver.Service(labels[0])
+		}
+	case "label":
+		// the value may contain dots
+		if len(labels) >= 3 {
+			return s.resolver.Label(labels[len(labels)-2], strings.Join(labels[:len(labels)-2], "."))
+		}
+	}
+	return nil
+}
+
+// answer returns the A or AAAA records of the IPs matching the question,
+// the other questions of an existing name have no records
+func (s *Server) answer(header dnsmessage.Header, q dnsmessage.Question, ips []net.IP, truncated bool) ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:                 header.ID,
+		Response:           true,
+		Authoritative:      true,
+		Truncated:          truncated,
+		RecursionDesired:   header.RecursionDesired,
+		RecursionAvailable: true,
+	})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	rh := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: s.ttl}
+	for _, ip := range ips {
+		ip4 := ip.To4()
+		switch {
+		case ip4 != nil && (q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeALL):
+			var r dnsmessage.AResource
+			copy(r.A[:], ip4)
+			if err := b.AResource(rh, r); err != nil {
+				return nil, err
+			}
+		case ip4 == nil && (q.Type == dnsmessage.TypeAAAA || q.Type == dnsmessage.TypeALL):
+			var r dnsmessage.AAAAResource
+			copy(r.AAAA[:], ip.To16())
+			if err := b.AAAAResource(rh, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.Finish()
+}
+
+// reply returns an answer without records, the authoritative answers are for the names in the domain of the server
+func reply(header dnsmessage.Header, q *dnsmessage.Question, rcode dnsmessage.RCode, authoritative bool) []byte {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:                 header.ID,
+		Response:           true,
+		OpCode:             header.OpCode,
+		Authoritative:      authoritative,
+		RecursionDesired:   header.RecursionDesired,
+		RecursionAvailable: true,
+		RCode:              rcode,
+	})
+	if q != nil {
+		if b.StartQuestions() != nil || b.Question(*q) != nil {
+			return nil
+		}
+	}
+	msg, err := b.Finish()
+	if err != nil {
+		return nil
+	}
+	return msg
+}
+
+// forward sends the query to the upstream nameservers over the network the client used until one answers
+func (s *Server) forward(query []byte, network string) ([]byte, error) {
+	if len(s.upstream) == 0 {
+		return nil, errors.New("no upstream nameservers")
+	}
+	var err error
+	for _, ns := range s.upstream {
+		var answer []byte
+		if answer, err = exchange(query, network, ns); err == nil {
+			return answer, nil
+		}
+	}
+	return nil, err
+}
+
+func exchange(query []byte, network, ns string) ([]byte, error) {
+	conn, err := net.DialTimeout(network, ns, forwardTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(forwardTimeout))
+	if network == "tcp" {
+		if err := writeTCP(conn, query); err != nil {
+			return nil, err
+		}
+		return readTCP(conn)
+	}
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, maxMessageSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
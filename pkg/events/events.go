@@ -0,0 +1,75 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/combust-labs/firebox/pkg/metrics"
+)
+
+const (
+	// Hibernated is recorded when an idle VM is snapshotted to disk and stopped
+	Hibernated = "hibernated"
+	// Restored is recorded when a hibernated VM is restored to serve an invocation
+	Restored = "restored"
+	// NetworkFailed is recorded when the check of the network attachments of a VM starts failing
+	NetworkFailed = "network_failed"
+	// NetworkRecovered is recorded when the check of the network attachments of a VM succeeds again
+	NetworkRecovered = "network_recovered"
+	// NetworkRepaired is recorded when the network interfaces of a VM are attached again
+	NetworkRepaired = "network_repaired"
+	// Replaced is recorded when a VM is stopped and a new VM with the same spec is started instead
+	Replaced = "replaced"
+)
+
+type Event struct {
+	Time    time.Time
+	Type    string
+	VMID    string
+	Service string
+	Message string
+}
+
+// Recorder keeps the latest events in memory
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+func NewRecorder(size int) *Recorder {
+	if size <= 0 {
+		size = 1
+	}
+	return &Recorder{
+		events: make([]Event, size),
+	}
+}
+
+func (r *Recorder) Record(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	metrics.VMEvents.WithLabelValues(event.Type).Inc()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[r.next] = event
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// List returns the recorded events, the oldest first
+func (r *Recorder) List() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]Event{}, r.events[:r.next]...)
+	}
+	return append(append([]Event{}, r.events[r.next:]...), r.events[:r.next]...)
+}
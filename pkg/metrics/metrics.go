@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "firebox"
+
+const (
+	StateReady   = "ready"
+	StateUnready = "unready"
+	StatePaused  = "paused"
+
+	ProbeSuccess = "success"
+	ProbeFailure = "failure"
+
+	// StatusError is used as invocation status when no response was received from the guest
+	StatusError = "error"
+	// ServiceUnknown is used as service of the invocations and machines of services which are not configured
+	ServiceUnknown = "unknown"
+)
+
+var (
+	VMStarts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "vm_starts_total",
+		Help:      "Number of VM start attempts.",
+	})
+	VMStartFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "vm_start_failures_total",
+		Help:      "Number of VM starts which failed.",
+	})
+	VMBootToReady = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "vm_boot_to_ready_seconds",
+		Help:      "Time from the VM start request until the first successful readiness probe.",
+		Buckets:   []float64{.1, .25, .5, 1, 2, 3, 5, 10, 20, 30, 60},
+	})
+	VMs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "vms",
+		Help:      "Number of running VMs by state.",
+	}, []string{"state"})
+
+	VMEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "vm_events_total",
+		Help:      "Number of VM lifecycle events by type, e.g. hibernated or restored.",
+	}, []string{"event"})
+
+	ProbeResults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "probe_results_total",
+		Help:      "Number of VM readiness probes by result.",
+	}, []string{"result"})
+
+	Invocations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invocations_total",
+		Help:      "Number of service invocations by service and response status.",
+	}, []string{"service", "status"})
+	InvokeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "invoke_duration_seconds",
+		Help:      "Latency of service invocations.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service"})
+	InvokeQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "invoke_queue_depth",
+		Help:      "Number of invocations waiting for or being served by a VM.",
+	})
+
+	FirecrackerMetrics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "firecracker_metrics_total",
+		Help:      "Firecracker counters of the VMs by service, accumulated from the Firecracker metrics flushes.",
+	}, []string{"service", "group", "metric"})
+	FirecrackerGauges = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "firecracker_gauges",
+		Help:      "Firecracker gauges, e.g. latencies, of the running VMs, the last flushed value.",
+	}, []string{"vmid", "group", "metric"})
+)
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveInvoke records the outcome of a single invocation, statusCode is ignored when err is set
+func ObserveInvoke(service string, statusCode int64, err error, duration time.Duration) {
+	status := StatusError
+	if err == nil {
+		status = strconv.FormatInt(statusCode, 10)
+	}
+	Invocations.WithLabelValues(service, status).Inc()
+	InvokeDuration.WithLabelValues(service).Observe(duration.Seconds())
+}
+
+func SetVMs(ready, unready, paused int) {
+	VMs.WithLabelValues(StateReady).Set(float64(ready))
+	VMs.WithLabelValues(StateUnready).Set(float64(unready))
+	VMs.WithLabelValues(StatePaused).Set(float64(paused))
+}
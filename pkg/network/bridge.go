@@ -0,0 +1,287 @@
+// Package network implements the built-in network of the machines used when CNI is disabled:
+// a bridge on the host with a tap device per machine and static guest IPs.
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os/exec"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// maxNameservers is the number of nameservers the kernel ip= argument can carry
+const maxNameservers = 2
+
+// ErrTapNotFound is returned for a tap device which does not exist
+var ErrTapNotFound = errors.New("tap device not found")
+
+// ParseSubnet returns the subnet of the bridge and its gateway, the first address of the subnet
+func ParseSubnet(subnet string) (*net.IPNet, net.IP, error) {
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid bridge subnet '%s'", subnet)
+	}
+	if ipNet.IP.To4() == nil {
+		return nil, nil, errors.Errorf("bridge subnet '%s' is not an IPv4 subnet", subnet)
+	}
+	if ones, bits := ipNet.Mask.Size(); bits-ones < 2 {
+		return nil, nil, errors.Errorf("bridge subnet '%s' is too small", subnet)
+	}
+	return ipNet, nextIP(ipNet.IP.To4()), nil
+}
+
+// ParseSubnet6 returns the IPv6 subnet of a dual-stack bridge and its gateway, the first address of the subnet.
+// The subnet must have room for the guest addresses at the offsets of the guest IPs in the IPv4 subnet.
+func ParseSubnet6(subnet6 string, subnet *net.IPNet) (*net.IPNet, net.IP, error) {
+	ip, ipNet, err := net.ParseCIDR(subnet6)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid bridge IPv6 subnet '%s'", subnet6)
+	}
+	if ip.To4() != nil {
+		return nil, nil, errors.Errorf("bridge IPv6 subnet '%s' is not an IPv6 subnet", subnet6)
+	}
+	ones4, bits4 := subnet.Mask.Size()
+	if ones, bits := ipNet.Mask.Size(); bits-ones < bits4-ones4 {
+		return nil, nil, errors.Errorf("bridge IPv6 subnet '%s' is smaller than the subnet '%s'", subnet6, subnet)
+	}
+	return ipNet, nextIP(ipNet.IP), nil
+}
+
+// GuestIP6 returns the IPv6 address of the guest with the given IP, it has the offset of the IP in the IPv4 subnet
+func GuestIP6(subnet6, subnet *net.IPNet, ip net.IP) net.IP {
+	ip4, base := ip.To4(), subnet.IP.To4()
+	result := make(net.IP, net.IPv6len)
+	copy(result, subnet6.IP.To16())
+	for i := 0; i < net.IPv4len; i++ {
+		result[net.IPv6len-net.IPv4len+i] |= ip4[i] - base[i]
+	}
+	return result
+}
+
+// EnsureBridge creates the bridge with the gateway address unless it exists and brings it up.
+// With Masquerade the traffic of the guests leaving the subnet is masqueraded.
+func EnsureBridge(cfg config.BridgeConfig) error {
+	subnet, gateway, err := ParseSubnet(cfg.Subnet)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Nameservers) > maxNameservers {
+		return errors.Errorf("at most %d nameservers are supported", maxNameservers)
+	}
+	for _, ns := range cfg.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return errors.Errorf("invalid nameserver '%s'", ns)
+		}
+	}
+
+	link, err := netlink.LinkByName(cfg.Name)
+	if _, ok := err.(netlink.LinkNotFoundError); ok {
+		if err := netlink.LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: cfg.Name}}); err != nil {
+			return errors.Wrapf(err, "creating bridge %s failed", cfg.Name)
+		}
+		link, err = netlink.LinkByName(cfg.Name)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "looking up bridge %s failed", cfg.Name)
+	}
+	if _, ok := link.(*netlink.Bridge); !ok {
+		return errors.Errorf("link %s is not a bridge", cfg.Name)
+	}
+	addr := &netlink.Addr{IPNet: &net.IPNet{IP: gateway, Mask: subnet.Mask}}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+	if err != nil {
+		return errors.Wrapf(err, "listing addresses of bridge %s failed", cfg.Name)
+	}
+	if !hasAddr(addrs, addr) {
+		if err := netlink.AddrAdd(link, addr); err != nil {
+			return errors.Wrapf(err, "adding address %s to bridge %s failed", addr.IPNet, cfg.Name)
+		}
+	}
+	var subnet6 *net.IPNet
+	if cfg.Subnet6 != "" {
+		var gateway6 net.IP
+		if subnet6, gateway6, err = ParseSubnet6(cfg.Subnet6, subnet); err != nil {
+			return err
+		}
+		// the gateway is the only address on the bridge, the duplicate address detection would delay it
+		addr6 := &netlink.Addr{IPNet: &net.IPNet{IP: gateway6, Mask: subnet6.Mask}, Flags: unix.IFA_F_NODAD}
+		addrs6, err := netlink.AddrList(link, netlink.FAMILY_V6)
+		if err != nil {
+			return errors.Wrapf(err, "listing addresses of bridge %s failed", cfg.Name)
+		}
+		if !hasAddr(addrs6, addr6) {
+			if err := netlink.AddrAdd(link, addr6); err != nil {
+				return errors.Wrapf(err, "adding address %s to bridge %s failed", addr6.IPNet, cfg.Name)
+			}
+		}
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return errors.Wrapf(err, "bringing bridge %s up failed", cfg.Name)
+	}
+	if !cfg.Masquerade {
+		return nil
+	}
+	if err := masquerade(cfg.Name, subnet); err != nil {
+		return err
+	}
+	if subnet6 != nil {
+		return masquerade(cfg.Name, subnet6)
+	}
+	return nil
+}
+
+func hasAddr(addrs []netlink.Addr, addr *netlink.Addr) bool {
+	for _, a := range addrs {
+		if a.IPNet.String() == addr.IPNet.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// masquerade enables the IP forwarding of the family of the subnet and adds the masquerading rule unless it exists
+func masquerade(bridge string, subnet *net.IPNet) error {
+	forwarding := "/proc/sys/net/ipv4/ip_forward"
+	if subnet.IP.To4() == nil {
+		forwarding = "/proc/sys/net/ipv6/conf/all/forwarding"
+	}
+	if err := ioutil.WriteFile(forwarding, []byte("1"), 0644); err != nil {
+		return errors.Wrap(err, "enabling IP forwarding failed")
+	}
+	tool := iptablesCommand(subnet.IP)
+	rule := masqueradeRule(bridge, subnet)
+	if exec.Command(tool, append([]string{"-t", "nat", "-C"}, rule...)...).Run() == nil {
+		return nil
+	}
+	if out, err := exec.Command(tool, append([]string{"-t", "nat", "-A"}, rule...)...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "adding masquerading rule for %s failed: %s", subnet, out)
+	}
+	return nil
+}
+
+func masqueradeRule(bridge string, subnet *net.IPNet) []string {
+	return []string{"POSTROUTING", "-s", subnet.String(), "!", "-o", bridge, "-j", "MASQUERADE"}
+}
+
+// CheckTap checks the tap device is up and attached to the bridge which is up,
+// with Masquerade the masquerading rules of the bridge subnets must exist
+func CheckTap(name string, cfg config.BridgeConfig) error {
+	br, err := netlink.LinkByName(cfg.Name)
+	if err != nil {
+		return errors.Wrapf(err, "looking up bridge %s failed", cfg.Name)
+	}
+	if br.Attrs().Flags&net.FlagUp == 0 {
+		return errors.Errorf("bridge %s is down", cfg.Name)
+	}
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return errors.Wrapf(err, "looking up tap %s failed", name)
+	}
+	if link.Attrs().MasterIndex != br.Attrs().Index {
+		return errors.Errorf("tap %s is not attached to bridge %s", name, cfg.Name)
+	}
+	if link.Attrs().Flags&net.FlagUp == 0 {
+		return errors.Errorf("tap %s is down", name)
+	}
+	if cfg.Masquerade {
+		subnet, _, err := ParseSubnet(cfg.Subnet)
+		if err != nil {
+			return err
+		}
+		subnets := []*net.IPNet{subnet}
+		if cfg.Subnet6 != "" {
+			subnet6, _, err := ParseSubnet6(cfg.Subnet6, subnet)
+			if err != nil {
+				return err
+			}
+			subnets = append(subnets, subnet6)
+		}
+		for _, s := range subnets {
+			rule := masqueradeRule(cfg.Name, s)
+			if err := exec.Command(iptablesCommand(s.IP), append([]string{"-t", "nat", "-C"}, rule...)...).Run(); err != nil {
+				return errors.Errorf("masquerading rule for %s is missing", s)
+			}
+		}
+	}
+	return nil
+}
+
+// TapName returns the name of the tap device of the guest with the given IP.
+// The name depends on the IP only since a machine restored from a snapshot reopens the tap device of the snapshot.
+func TapName(ip net.IP) string {
+	ip4 := ip.To4()
+	return fmt.Sprintf("fb%02x%02x%02x%02x", ip4[0], ip4[1], ip4[2], ip4[3])
+}
+
+// MacAddress returns the locally administered MAC address of the guest with the given IP
+func MacAddress(ip net.IP) string {
+	ip4 := ip.To4()
+	return fmt.Sprintf("02:fc:%02x:%02x:%02x:%02x", ip4[0], ip4[1], ip4[2], ip4[3])
+}
+
+// CreateTap creates the persistent tap device attached to the bridge, an existing device is reused
+func CreateTap(name, bridge string) error {
+	if _, err := netlink.LinkByName(bridge); err != nil {
+		return errors.Wrapf(err, "looking up bridge %s failed", bridge)
+	}
+	_, err := netlink.LinkByName(name)
+	if _, ok := err.(netlink.LinkNotFoundError); ok {
+		tap := &netlink.Tuntap{
+			LinkAttrs: netlink.LinkAttrs{Name: name},
+			Mode:      netlink.TUNTAP_MODE_TAP,
+			Flags:     netlink.TUNTAP_ONE_QUEUE | netlink.TUNTAP_NO_PI | netlink.TUNTAP_VNET_HDR,
+		}
+		if err := netlink.LinkAdd(tap); err != nil {
+			return errors.Wrapf(err, "creating tap %s failed", name)
+		}
+		// the device is persistent, Firecracker opens it again
+		for _, fd := range tap.Fds {
+			_ = fd.Close()
+		}
+	} else if err != nil {
+		return errors.Wrapf(err, "looking up tap %s failed", name)
+	}
+	return AttachTap(name, bridge)
+}
+
+// AttachTap attaches the existing tap device to the bridge and brings it up
+func AttachTap(name, bridge string) error {
+	br, err := netlink.LinkByName(bridge)
+	if err != nil {
+		return errors.Wrapf(err, "looking up bridge %s failed", bridge)
+	}
+	link, err := netlink.LinkByName(name)
+	if _, ok := err.(netlink.LinkNotFoundError); ok {
+		return errors.Wrapf(ErrTapNotFound, "'%s'", name)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "looking up tap %s failed", name)
+	}
+	if err := netlink.LinkSetMaster(link, br); err != nil {
+		return errors.Wrapf(err, "attaching tap %s to bridge %s failed", name, bridge)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return errors.Wrapf(err, "bringing tap %s up failed", name)
+	}
+	return nil
+}
+
+// DeleteTap deletes the tap device, a missing device is ignored
+func DeleteTap(name string) error {
+	link, err := netlink.LinkByName(name)
+	if _, ok := err.(netlink.LinkNotFoundError); ok {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "looking up tap %s failed", name)
+	}
+	if err := netlink.LinkDel(link); err != nil {
+		return errors.Wrapf(err, "deleting tap %s failed", name)
+	}
+	return nil
+}
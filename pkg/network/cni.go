@@ -0,0 +1,141 @@
+package network
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/containernetworking/cni/libcni"
+	"github.com/pkg/errors"
+)
+
+// CNIConfVersion is the CNI spec version of the generated network configuration lists
+const CNIConfVersion = "0.4.0"
+
+type cniConfList struct {
+	Name       string        `json:"name"`
+	CNIVersion string        `json:"cniVersion"`
+	Plugins    []interface{} `json:"plugins"`
+}
+
+type cniBridgePlugin struct {
+	Type             string           `json:"type"`
+	Name             string           `json:"name"`
+	Bridge           string           `json:"bridge"`
+	IsDefaultGateway bool             `json:"isDefaultGateway"`
+	IPMasq           bool             `json:"ipMasq"`
+	HairpinMode      bool             `json:"hairpinMode"`
+	IPAM             cniHostLocalIPAM `json:"ipam"`
+	Capabilities     map[string]bool  `json:"capabilities"`
+}
+
+type cniHostLocalIPAM struct {
+	Type       string                `json:"type"`
+	Subnet     string                `json:"subnet,omitempty"`
+	Ranges     [][]cniHostLocalRange `json:"ranges,omitempty"`
+	ResolvConf string                `json:"resolvConf"`
+}
+
+type cniHostLocalRange struct {
+	Subnet string `json:"subnet"`
+}
+
+type cniPlugin struct {
+	Type         string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities,omitempty"`
+}
+
+// CNIConfFile returns the path of the generated network configuration list
+func CNIConfFile(confDir, name string) string {
+	return filepath.Join(confDir, "50-"+name+".conflist")
+}
+
+// CNIConfList returns the network configuration list of a bridge network for the VMs: the bridge plugin with the
+// host-local IPAM, the firewall, portmap and bandwidth plugins and the tc-redirect-tap plugin creating the tap device
+// of the VM. The plugins declare the capabilities of the port mappings, bandwidth, IPs and MAC of the VM interfaces.
+// The subnet may be an IPv4 or an IPv6 subnet, with subnet6 the VMs get an address of each subnet.
+// With ipMasq the traffic of the VMs leaving the subnets is masqueraded.
+func CNIConfList(name, bridge, subnet, subnet6 string, ipMasq bool) ([]byte, error) {
+	if name == "" || bridge == "" {
+		return nil, errors.New("network and bridge name are required")
+	}
+	if _, _, err := net.ParseCIDR(subnet); err != nil {
+		return nil, errors.Wrapf(err, "invalid subnet '%s'", subnet)
+	}
+	ipam := cniHostLocalIPAM{Type: "host-local", Subnet: subnet, ResolvConf: "/etc/resolv.conf"}
+	if subnet6 != "" {
+		ip, _, err := net.ParseCIDR(subnet6)
+		if err != nil || ip.To4() != nil {
+			return nil, errors.Errorf("invalid IPv6 subnet '%s'", subnet6)
+		}
+		ipam.Subnet = ""
+		ipam.Ranges = [][]cniHostLocalRange{{{Subnet: subnet}}, {{Subnet: subnet6}}}
+	}
+	data, err := json.MarshalIndent(cniConfList{
+		Name:       name,
+		CNIVersion: CNIConfVersion,
+		Plugins: []interface{}{
+			cniBridgePlugin{
+				Type:             "bridge",
+				Name:             bridge,
+				Bridge:           bridge,
+				IsDefaultGateway: true,
+				IPMasq:           ipMasq,
+				HairpinMode:      true,
+				IPAM:             ipam,
+				Capabilities:     map[string]bool{"ips": true, "mac": true},
+			},
+			cniPlugin{Type: "firewall"},
+			cniPlugin{Type: "portmap", Capabilities: map[string]bool{"portMappings": true}},
+			cniPlugin{Type: "bandwidth", Capabilities: map[string]bool{"bandwidth": true}},
+			// tc-redirect-tap must be the last plugin
+			cniPlugin{Type: "tc-redirect-tap"},
+		},
+	}, "", "    ")
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding network configuration list failed")
+	}
+	return append(data, '\n'), nil
+}
+
+// ValidateCNI checks the network configuration lists of the networks exist in the configuration directory,
+// end with the tc-redirect-tap plugin and the plugins they use, including the IPAM plugins, are executables
+// in the binaries directory
+func ValidateCNI(cni config.CNIConfig, networks []string) error {
+	for _, name := range networks {
+		list, err := libcni.LoadConfList(cni.ConfDir, name)
+		if errors.As(err, &libcni.NotFoundError{}) || errors.As(err, &libcni.NoConfigsFoundError{}) {
+			return errors.Errorf("CNI network '%s' is not configured in %s, generate it with 'firebox network init --cni-network-name %s'", name, cni.ConfDir, name)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "loading CNI network '%s' from %s failed", name, cni.ConfDir)
+		}
+		if n := len(list.Plugins); n == 0 || list.Plugins[n-1].Network.Type != "tc-redirect-tap" {
+			return errors.Errorf("CNI network '%s' in %s must end with the tc-redirect-tap plugin", name, cni.ConfDir)
+		}
+		var missing []string
+		for _, plugin := range list.Plugins {
+			types := []string{plugin.Network.Type}
+			if plugin.Network.IPAM.Type != "" {
+				types = append(types, plugin.Network.IPAM.Type)
+			}
+			for _, t := range types {
+				if !isExecutable(filepath.Join(cni.BinDir, t)) {
+					missing = append(missing, t)
+				}
+			}
+		}
+		if len(missing) > 0 {
+			return errors.Errorf("CNI network '%s' uses the plugins %s missing in %s", name, strings.Join(missing, ", "), cni.BinDir)
+		}
+	}
+	return nil
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode()&0111 != 0
+}
@@ -0,0 +1,343 @@
+package network
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+var (
+	ErrExhausted   = errors.New("no free IP in the bridge subnet")
+	ErrAllocated   = errors.New("IP already allocated")
+	ErrOutOfSubnet = errors.New("IP outside of the bridge subnet")
+	ErrConflict    = errors.New("IP in use on the bridge")
+	ErrNotOwner    = errors.New("IP leased by another machine")
+)
+
+// inUseStates are the neighbour states of the addresses answering on the bridge
+const inUseStates = netlink.NUD_PERMANENT | netlink.NUD_REACHABLE | netlink.NUD_DELAY | netlink.NUD_PROBE
+
+// Lease is an allocated guest IP
+type Lease struct {
+	IP string `json:"ip"`
+	// VMID of the machine owning the IP, empty while the machine is starting
+	VMID string `json:"vmid,omitempty"`
+	// PID of the firebox process running the machine
+	PID     int       `json:"pid,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// IPAM allocates the guest IPs of the bridge subnet, the network and broadcast addresses and the gateway
+// are never allocated. The leases are kept in a file shared by the firebox processes using the bridge,
+// a lease records the process running the machine.
+type IPAM struct {
+	mu      sync.Mutex
+	file    string
+	bridge  string
+	subnet  *net.IPNet
+	gateway net.IP
+	// last is the last allocated IP, the allocation continues after it to delay the reuse of released IPs
+	last net.IP
+}
+
+func NewIPAM(cfg config.BridgeConfig, dir string) (*IPAM, error) {
+	ipNet, gateway, err := ParseSubnet(cfg.Subnet)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "creating network dir %s failed", dir)
+	}
+	return &IPAM{
+		file:    filepath.Join(dir, cfg.Name+"-leases.json"),
+		bridge:  cfg.Name,
+		subnet:  ipNet,
+		gateway: gateway,
+		last:    gateway,
+	}, nil
+}
+
+func (a *IPAM) Subnet() *net.IPNet {
+	return a.subnet
+}
+
+func (a *IPAM) Gateway() net.IP {
+	return a.gateway
+}
+
+// Allocate leases the next free IP of the subnet, the IPs in use on the bridge are skipped
+func (a *IPAM) Allocate() (ip net.IP, err error) {
+	err = a.update(func(leases map[string]Lease) error {
+		inUse, err := a.inUse()
+		if err != nil {
+			return err
+		}
+		ip = a.last
+		for {
+			ip = nextIP(ip)
+			if !a.subnet.Contains(ip) {
+				ip = a.subnet.IP.To4()
+				continue
+			}
+			if _, leased := leases[ip.String()]; a.usable(ip) && !leased && !inUse[ip.String()] {
+				leases[ip.String()] = newLease(ip)
+				a.last = ip
+				return nil
+			}
+			if ip.Equal(a.last) {
+				return ErrExhausted
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ip, nil
+}
+
+// Reserve leases the given IP, it fails when the IP is leased already or in use on the bridge
+func (a *IPAM) Reserve(ip net.IP) error {
+	ip = ip.To4()
+	if ip == nil || !a.subnet.Contains(ip) || !a.usable(ip) {
+		return errors.Wrapf(ErrOutOfSubnet, "'%s'", ip)
+	}
+	return a.update(func(leases map[string]Lease) error {
+		if _, leased := leases[ip.String()]; leased {
+			return errors.Wrapf(ErrAllocated, "'%s'", ip)
+		}
+		inUse, err := a.inUse()
+		if err != nil {
+			return err
+		}
+		if inUse[ip.String()] {
+			return errors.Wrapf(ErrConflict, "'%s'", ip)
+		}
+		leases[ip.String()] = newLease(ip)
+		return nil
+	})
+}
+
+func newLease(ip net.IP) Lease {
+	return Lease{IP: ip.String(), PID: os.Getpid(), Created: time.Now().UTC()}
+}
+
+// Bind records the machine owning the leased IP and the process running it
+func (a *IPAM) Bind(ip net.IP, vmid string) error {
+	return a.update(func(leases map[string]Lease) error {
+		lease, ok := leases[ip.To4().String()]
+		if !ok {
+			return errors.Errorf("IP '%s' is not leased", ip)
+		}
+		lease.VMID = vmid
+		lease.PID = os.Getpid()
+		leases[lease.IP] = lease
+		return nil
+	})
+}
+
+// Release releases the IP leased by the machine, the IP of a starting machine is released by the process
+// which leased it. Releasing an IP which is not leased succeeds.
+func (a *IPAM) Release(ip net.IP, vmid string) error {
+	return a.update(func(leases map[string]Lease) error {
+		lease, ok := leases[ip.To4().String()]
+		if !ok {
+			return nil
+		}
+		if lease.VMID != vmid || (vmid == "" && lease.PID != os.Getpid()) {
+			return errors.Wrapf(ErrNotOwner, "'%s' by vmid '%s'", ip, lease.VMID)
+		}
+		delete(leases, lease.IP)
+		return nil
+	})
+}
+
+// Prune releases the leases outside of the subnet and the leases of the exited processes keep returns false for,
+// the leases of the running processes are kept
+func (a *IPAM) Prune(keep func(Lease) bool) ([]Lease, error) {
+	var pruned []Lease
+	err := a.update(func(leases map[string]Lease) error {
+		for key, lease := range leases {
+			if ip := net.ParseIP(lease.IP); ip == nil || !a.subnet.Contains(ip) || !running(lease.PID) && !keep(lease) {
+				pruned = append(pruned, lease)
+				delete(leases, key)
+			}
+		}
+		return nil
+	})
+	return pruned, err
+}
+
+// running is true when the process with the pid exists
+func running(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+// Leases returns the leases ordered by IP
+func (a *IPAM) Leases() ([]Lease, error) {
+	unlock, err := a.lock(syscall.LOCK_SH)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	leases, err := a.read()
+	if err != nil {
+		return nil, err
+	}
+	return sortedLeases(leases), nil
+}
+
+// update runs fn with the leases of the file locked against the other processes and writes the changed leases back
+func (a *IPAM) update(fn func(leases map[string]Lease) error) error {
+	unlock, err := a.lock(syscall.LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	leases, err := a.read()
+	if err != nil {
+		return err
+	}
+	if err := fn(leases); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(sortedLeases(leases), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding leases failed")
+	}
+	tmp := a.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "writing leases failed")
+	}
+	if err := os.Rename(tmp, a.file); err != nil {
+		return errors.Wrapf(err, "renaming %s failed", tmp)
+	}
+	return nil
+}
+
+// lock locks the leases against the other goroutines and, by the lock file, against the other processes
+func (a *IPAM) lock(how int) (func(), error) {
+	a.mu.Lock()
+	lock, err := os.OpenFile(a.file+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		a.mu.Unlock()
+		return nil, errors.Wrap(err, "opening leases lock failed")
+	}
+	if err := syscall.Flock(int(lock.Fd()), how); err != nil {
+		lock.Close()
+		a.mu.Unlock()
+		return nil, errors.Wrap(err, "locking leases failed")
+	}
+	return func() {
+		_ = syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+		lock.Close()
+		a.mu.Unlock()
+	}, nil
+}
+
+// read returns the leases of the file by IP
+func (a *IPAM) read() (map[string]Lease, error) {
+	leases := make(map[string]Lease)
+	data, err := ioutil.ReadFile(a.file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "reading leases failed")
+	}
+	if len(data) > 0 {
+		var list []Lease
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, errors.Wrapf(err, "decoding leases %s failed", a.file)
+		}
+		for _, lease := range list {
+			leases[lease.IP] = lease
+		}
+	}
+	return leases, nil
+}
+
+func sortedLeases(leases map[string]Lease) []Lease {
+	list := make([]Lease, 0, len(leases))
+	for _, lease := range leases {
+		list = append(list, lease)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return ipLess(net.ParseIP(list[i].IP), net.ParseIP(list[j].IP))
+	})
+	return list
+}
+
+// inUse returns the addresses of the bridge and of the neighbours answering on it
+func (a *IPAM) inUse() (map[string]bool, error) {
+	link, err := netlink.LinkByName(a.bridge)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up bridge %s failed", a.bridge)
+	}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing addresses of bridge %s failed", a.bridge)
+	}
+	neighs, err := netlink.NeighList(link.Attrs().Index, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing neighbours of bridge %s failed", a.bridge)
+	}
+	inUse := make(map[string]bool)
+	for _, addr := range addrs {
+		inUse[addr.IP.String()] = true
+	}
+	for _, neigh := range neighs {
+		if neigh.State&inUseStates != 0 {
+			inUse[neigh.IP.String()] = true
+		}
+	}
+	return inUse, nil
+}
+
+func (a *IPAM) usable(ip net.IP) bool {
+	return !ip.Equal(a.subnet.IP) && !ip.Equal(a.gateway) && !ip.Equal(broadcast(a.subnet))
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i]++; next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+func ipLess(a, b net.IP) bool {
+	a, b = a.To16(), b.To16()
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func broadcast(subnet *net.IPNet) net.IP {
+	ip := subnet.IP.To4()
+	b := make(net.IP, len(ip))
+	for i := range ip {
+		b[i] = ip[i] | ^subnet.Mask[i]
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// CreateNetNS creates a new network namespace bind mounted at path like `ip netns add` does
+func CreateNetNS(path string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "creating netns dir %s failed", filepath.Dir(path))
+	}
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE|os.O_EXCL, 0444)
+	if err != nil {
+		return errors.Wrapf(err, "creating netns file %s failed", path)
+	}
+	_ = f.Close()
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		// the thread is not unlocked, it is terminated with the goroutine since its namespace changed
+		runtime.LockOSThread()
+		if err := unix.Unshare(unix.CLONE_NEWNET); err != nil {
+			done <- errors.Wrap(err, "unsharing netns failed")
+			return
+		}
+		if err := unix.Mount("/proc/thread-self/ns/net", path, "none", unix.MS_BIND, ""); err != nil {
+			done <- errors.Wrapf(err, "mounting netns at %s failed", path)
+			return
+		}
+		done <- nil
+	}()
+	return <-done
+}
+
+// DeleteNetNS unmounts and removes the network namespace at path, a missing namespace is ignored
+func DeleteNetNS(path string) error {
+	if err := unix.Unmount(path, unix.MNT_DETACH); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+		return errors.Wrapf(err, "unmounting netns %s failed", path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "removing netns %s failed", path)
+	}
+	return nil
+}
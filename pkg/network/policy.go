@@ -0,0 +1,300 @@
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// bridgeNFCall are the sysctls passing the bridged traffic through the iptables and ip6tables chains,
+// they exist with br_netfilter loaded
+var bridgeNFCall = []string{"/proc/sys/net/bridge/bridge-nf-call-iptables", "/proc/sys/net/bridge/bridge-nf-call-ip6tables"}
+
+// policyCommands returns the commands the policy of the guest with the IPs is applied by,
+// ip6tables is required by the guests with IPv6 addresses only
+func policyCommands(ips []net.IP) []string {
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			return []string{"iptables", "ip6tables"}
+		}
+	}
+	return []string{"iptables"}
+}
+
+// iptablesCommand returns the iptables command of the family of the IP
+func iptablesCommand(ip net.IP) string {
+	if ip.To4() == nil {
+		return "ip6tables"
+	}
+	return "iptables"
+}
+
+// ValidatePolicy checks the CIDRs, ports and protocols of the rules of the policy
+func ValidatePolicy(policy config.NetworkPolicyConfig) error {
+	for _, rules := range [][]config.NetworkPolicyRule{policy.Egress, policy.Ingress} {
+		for _, rule := range rules {
+			for _, cidr := range rule.CIDRs {
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					return errors.Errorf("invalid CIDR '%s'", cidr)
+				}
+			}
+			switch rule.Protocol {
+			case "", "tcp", "udp", "sctp":
+			case "icmp":
+				if len(rule.Ports) > 0 {
+					return errors.New("ports require the protocol tcp, udp or sctp")
+				}
+			default:
+				return errors.Errorf("invalid protocol '%s'", rule.Protocol)
+			}
+			for _, port := range rule.Ports {
+				if _, err := portRange(port); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// portRange converts a port or a port range like 8000-8080 to the iptables notation
+func portRange(port string) (string, error) {
+	parts := strings.SplitN(port, "-", 2)
+	var ports []int
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 || n > 65535 {
+			return "", errors.Errorf("invalid port '%s'", port)
+		}
+		ports = append(ports, n)
+	}
+	if len(ports) == 2 && ports[0] > ports[1] {
+		return "", errors.Errorf("invalid port range '%s'", port)
+	}
+	return strings.Join(parts, ":"), nil
+}
+
+// policyChains returns the chains of the egress and ingress rules of the guest behind the host device
+func policyChains(dev string) (string, string) {
+	return "FBE-" + dev, "FBI-" + dev
+}
+
+// ApplyPolicy restricts the traffic of the guest with the given IPs behind the host device to the rules of the
+// policy, the traffic which is not allowed is dropped. The egress is matched on the host device, the tap device on
+// the built-in network or the host side veth on CNI, and covers the traffic to the host, the other guests and the
+// internet. The ingress is matched on the guest IPs and covers the forwarded traffic, the host always reaches the
+// guests. The replies of the allowed connections and the IPv6 neighbor discovery are allowed.
+// Applying the policy again replaces the rules.
+func ApplyPolicy(dev string, ips []net.IP, policy config.NetworkPolicyConfig) error {
+	if err := ValidatePolicy(policy); err != nil {
+		return err
+	}
+	match, err := deviceMatch(dev)
+	if err != nil {
+		return err
+	}
+	egress, ingress := policyChains(dev)
+	for _, cmd := range policyCommands(ips) {
+		if err := policyChain(cmd, egress, "-d", policy.Egress); err != nil {
+			return err
+		}
+		if err := policyChain(cmd, ingress, "-s", policy.Ingress); err != nil {
+			return err
+		}
+		for _, jump := range policyJumps(match, familyIPs(cmd, ips), egress, ingress) {
+			if iptables(cmd, append([]string{"-C"}, jump...)...) == nil {
+				continue
+			}
+			// the jumps precede the rules accepting the traffic of the bridge or of the CNI plugins
+			if err := iptables(cmd, append([]string{"-I", jump[0], "1"}, jump[1:]...)...); err != nil {
+				return errors.Wrapf(err, "adding network policy of %s failed", dev)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckPolicy checks the rules of the policy of the guest with the given IPs behind the host device are in place
+func CheckPolicy(dev string, ips []net.IP) error {
+	match, err := deviceMatch(dev)
+	if err != nil {
+		return err
+	}
+	egress, ingress := policyChains(dev)
+	for _, cmd := range policyCommands(ips) {
+		for _, jump := range policyJumps(match, familyIPs(cmd, ips), egress, ingress) {
+			if err := iptables(cmd, append([]string{"-C"}, jump...)...); err != nil {
+				return errors.Errorf("network policy rule of %s in %s chain %s is missing", dev, cmd, jump[0])
+			}
+		}
+	}
+	return nil
+}
+
+// RemovePolicy deletes the rules of the policy of the guest with the given IPs behind the host device,
+// the missing rules are ignored. The host device may be deleted already.
+func RemovePolicy(dev string, ips []net.IP) error {
+	egress, ingress := policyChains(dev)
+	for _, cmd := range policyCommands(ips) {
+		var jumps [][]string
+		for _, match := range [][]string{physdevMatch(dev), {"-i", dev}} {
+			jumps = append(jumps, policyJumps(match, familyIPs(cmd, ips), egress, ingress)...)
+		}
+		for _, jump := range jumps {
+			for iptables(cmd, append([]string{"-C"}, jump...)...) == nil {
+				if err := iptables(cmd, append([]string{"-D"}, jump...)...); err != nil {
+					return errors.Wrapf(err, "deleting network policy of %s failed", dev)
+				}
+			}
+		}
+		for _, chain := range []string{egress, ingress} {
+			if iptables(cmd, "-n", "-L", chain) != nil {
+				continue
+			}
+			if err := iptables(cmd, "-F", chain); err != nil {
+				return errors.Wrapf(err, "deleting network policy of %s failed", dev)
+			}
+			if err := iptables(cmd, "-X", chain); err != nil {
+				return errors.Wrapf(err, "deleting network policy of %s failed", dev)
+			}
+		}
+	}
+	return nil
+}
+
+// familyIPs returns the IPs of the family of the iptables command
+func familyIPs(cmd string, ips []net.IP) []net.IP {
+	var result []net.IP
+	for _, ip := range ips {
+		if iptablesCommand(ip) == cmd {
+			result = append(result, ip)
+		}
+	}
+	return result
+}
+
+// deviceMatch returns the iptables match of the traffic entering the host by the device. The traffic of a bridge port
+// is matched by the physdev module which requires the bridged traffic to pass the iptables chains.
+func deviceMatch(dev string) ([]string, error) {
+	link, err := netlink.LinkByName(dev)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up %s failed", dev)
+	}
+	if link.Attrs().MasterIndex == 0 {
+		return []string{"-i", dev}, nil
+	}
+	for _, sysctl := range bridgeNFCall {
+		if err := ioutil.WriteFile(sysctl, []byte("1"), 0644); err != nil {
+			if os.IsNotExist(err) {
+				return nil, errors.New("network policies on bridges require the br_netfilter kernel module")
+			}
+			return nil, errors.Wrap(err, "enabling iptables on bridges failed")
+		}
+	}
+	return physdevMatch(dev), nil
+}
+
+func physdevMatch(dev string) []string {
+	return []string{"-m", "physdev", "--physdev-in", dev}
+}
+
+// policyJumps returns the rules of the built-in chains jumping to the chains of the policy,
+// the egress to the other guests and to the internet is forwarded, the egress to the host is input
+func policyJumps(match []string, ips []net.IP, egress, ingress string) [][]string {
+	var jumps [][]string
+	for _, chain := range []string{"FORWARD", "INPUT"} {
+		jump := append(append([]string{chain}, match...), "-j", egress)
+		jumps = append(jumps, jump)
+	}
+	for _, ip := range ips {
+		jumps = append(jumps, []string{"FORWARD", "-d", ip.String(), "-j", ingress})
+	}
+	return jumps
+}
+
+// policyChain creates or flushes the chain and adds the rules allowing the traffic to or from the CIDRs
+// of the family of the iptables command with the address option, the remaining traffic is dropped
+func policyChain(cmd, chain, addrOpt string, rules []config.NetworkPolicyRule) error {
+	if iptables(cmd, "-n", "-L", chain) != nil {
+		if err := iptables(cmd, "-N", chain); err != nil {
+			return errors.Wrapf(err, "creating chain %s failed", chain)
+		}
+	}
+	specs := [][]string{{"-m", "conntrack", "--ctstate", "ESTABLISHED,RELATED"}}
+	if cmd == "ip6tables" {
+		// the neighbor discovery replaces ARP
+		for _, t := range []string{"neighbour-solicitation", "neighbour-advertisement"} {
+			specs = append(specs, []string{"-p", "ipv6-icmp", "--icmpv6-type", t})
+		}
+	}
+	for _, rule := range rules {
+		specs = append(specs, ruleSpecs(cmd, addrOpt, rule)...)
+	}
+	if err := iptables(cmd, "-F", chain); err != nil {
+		return errors.Wrapf(err, "flushing chain %s failed", chain)
+	}
+	for _, spec := range specs {
+		if err := iptables(cmd, append(append([]string{"-A", chain}, spec...), "-j", "RETURN")...); err != nil {
+			return errors.Wrapf(err, "adding rule to chain %s failed", chain)
+		}
+	}
+	if err := iptables(cmd, "-A", chain, "-j", "DROP"); err != nil {
+		return errors.Wrapf(err, "adding rule to chain %s failed", chain)
+	}
+	return nil
+}
+
+// ruleSpecs returns the iptables matches of the rule, one per CIDR of the family of the command and port.
+// A rule with CIDRs of the other family only has no matches.
+func ruleSpecs(cmd, addrOpt string, rule config.NetworkPolicyRule) [][]string {
+	var cidrs []string
+	for _, cidr := range rule.CIDRs {
+		if ip, _, err := net.ParseCIDR(cidr); err == nil && iptablesCommand(ip) == cmd {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	if len(rule.CIDRs) == 0 {
+		cidrs = []string{""}
+	}
+	protocol := rule.Protocol
+	if protocol == "" && len(rule.Ports) > 0 {
+		protocol = "tcp"
+	}
+	if protocol == "icmp" && cmd == "ip6tables" {
+		protocol = "ipv6-icmp"
+	}
+	var specs [][]string
+	for _, cidr := range cidrs {
+		var spec []string
+		if cidr != "" {
+			spec = append(spec, addrOpt, cidr)
+		}
+		if protocol != "" {
+			spec = append(spec, "-p", protocol)
+		}
+		if len(rule.Ports) == 0 {
+			specs = append(specs, spec)
+			continue
+		}
+		for _, port := range rule.Ports {
+			ports, _ := portRange(port)
+			specs = append(specs, append(append([]string{}, spec...), "--dport", ports))
+		}
+	}
+	return specs
+}
+
+func iptables(cmd string, args ...string) error {
+	out, err := exec.Command(cmd, append([]string{"-w"}, args...)...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "%s %s: %s", cmd, strings.Join(args, " "), strings.TrimSpace(string(out)))
+	}
+	return nil
+}
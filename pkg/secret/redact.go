@@ -0,0 +1,35 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogHook redacts the secret values from the log messages and fields
+func (s *Store) LogHook() logrus.Hook {
+	return &redactHook{store: s}
+}
+
+type redactHook struct {
+	store *Store
+}
+
+func (h *redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *redactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = h.store.Redact(entry.Message)
+	for k, v := range entry.Data {
+		switch value := v.(type) {
+		case string:
+			entry.Data[k] = h.store.Redact(value)
+		case error:
+			entry.Data[k] = h.store.Redact(value.Error())
+		case fmt.Stringer:
+			entry.Data[k] = h.store.Redact(value.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	fileExt = ".enc"
+	keySize = 32
+	// Redacted replaces the secret values in the logs
+	Redacted = "[REDACTED]"
+	// values shorter than minRedactLength are not redacted, they would garble the logs
+	minRedactLength = 4
+)
+
+var (
+	ErrNotFound     = errors.New("secret not found")
+	ErrInvalidName  = errors.New("invalid secret name")
+	validSecretName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// Secret describes a stored secret, the value is never part of it
+type Secret struct {
+	Name string
+	// Version is incremented on every rotation
+	Version int64
+	Updated time.Time
+}
+
+type record struct {
+	Value   string    `json:"value"`
+	Version int64     `json:"version"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store keeps every secret encrypted with AES-256-GCM in its own file.
+// The values are cached in memory to be redacted from the logs.
+type Store struct {
+	mu     sync.RWMutex
+	dir    string
+	aead   cipher.AEAD
+	values map[string]string
+}
+
+// NewStore opens the store in dir, the key file is created with a random key when it does not exist
+func NewStore(dir, keyFile string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "creating secret dir %s failed", dir)
+	}
+	key, err := loadKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher failed")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher failed")
+	}
+	s := &Store{
+		dir:    dir,
+		aead:   aead,
+		values: make(map[string]string),
+	}
+	names, err := s.names()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		r, err := s.read(name)
+		if err != nil {
+			return nil, err
+		}
+		s.values[name] = r.Value
+	}
+	return s, nil
+}
+
+func loadKey(keyFile string) ([]byte, error) {
+	key, err := ioutil.ReadFile(keyFile)
+	if os.IsNotExist(err) {
+		key = make([]byte, keySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, errors.Wrap(err, "generating secret key failed")
+		}
+		if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+			return nil, errors.Wrapf(err, "creating secret key dir failed")
+		}
+		if err := ioutil.WriteFile(keyFile, key, 0400); err != nil {
+			return nil, errors.Wrapf(err, "writing secret key %s failed", keyFile)
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading secret key %s failed", keyFile)
+	}
+	if len(key) != keySize {
+		return nil, errors.Errorf("secret key %s must have %d bytes", keyFile, keySize)
+	}
+	return key, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+fileExt)
+}
+
+// Put stores the secret, the existing secret is rotated to the new value
+func (s *Store) Put(name, value string) (*Secret, error) {
+	if !validSecretName.MatchString(name) {
+		return nil, errors.Wrapf(ErrInvalidName, "'%s'", name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := record{Value: value, Version: 1, Updated: time.Now().UTC()}
+	if previous, err := s.read(name); err == nil {
+		r.Version = previous.Version + 1
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	if err := s.write(name, r); err != nil {
+		return nil, err
+	}
+	s.values[name] = value
+	return &Secret{Name: name, Version: r.Version, Updated: r.Updated}, nil
+}
+
+// Value returns the decrypted value of the secret
+func (s *Store) Value(name string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.values[name]
+	if !ok {
+		return "", errors.Wrapf(ErrNotFound, "'%s'", name)
+	}
+	return value, nil
+}
+
+func (s *Store) List() ([]Secret, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names, err := s.names()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Secret, 0, len(names))
+	for _, name := range names {
+		r, err := s.read(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Secret{Name: name, Version: r.Version, Updated: r.Updated})
+	}
+	return result, nil
+}
+
+func (s *Store) Delete(name string) error {
+	if !validSecretName.MatchString(name) {
+		return errors.Wrapf(ErrNotFound, "'%s'", name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(name)); os.IsNotExist(err) {
+		return errors.Wrapf(ErrNotFound, "'%s'", name)
+	} else if err != nil {
+		return errors.Wrapf(err, "deleting secret '%s' failed", name)
+	}
+	delete(s.values, name)
+	return nil
+}
+
+// Redact replaces the values of the stored secrets in s
+func (s *Store) Redact(text string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, value := range s.values {
+		if len(value) >= minRedactLength {
+			text = strings.ReplaceAll(text, value, Redacted)
+		}
+	}
+	return text
+}
+
+func (s *Store) names() ([]string, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading secret dir %s failed", s.dir)
+	}
+	var names []string
+	for _, f := range files {
+		if name := strings.TrimSuffix(f.Name(), fileExt); !f.IsDir() && name != f.Name() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *Store) read(name string) (record, error) {
+	var r record
+	data, err := ioutil.ReadFile(s.path(name))
+	if os.IsNotExist(err) {
+		return r, errors.Wrapf(ErrNotFound, "'%s'", name)
+	}
+	if err != nil {
+		return r, errors.Wrapf(err, "reading secret '%s' failed", name)
+	}
+	n := s.aead.NonceSize()
+	if len(data) < n {
+		return r, errors.Errorf("secret '%s' is corrupted", name)
+	}
+	// the name is authenticated, a secret file can not be renamed to another secret
+	plain, err := s.aead.Open(nil, data[:n], data[n:], []byte(name))
+	if err != nil {
+		return r, errors.Wrapf(err, "decrypting secret '%s' failed", name)
+	}
+	if err := json.Unmarshal(plain, &r); err != nil {
+		return r, errors.Wrapf(err, "decoding secret '%s' failed", name)
+	}
+	return r, nil
+}
+
+func (s *Store) write(name string, r record) error {
+	plain, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "encoding secret failed")
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "generating nonce failed")
+	}
+	data := s.aead.Seal(nonce, nonce, plain, []byte(name))
+	tmp := s.path(name) + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "writing secret '%s' failed", name)
+	}
+	if err := os.Rename(tmp, s.path(name)); err != nil {
+		return errors.Wrapf(err, "writing secret '%s' failed", name)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	metadataFile = "snapshot.json"
+	// snapshots are written into a temporary dir first and renamed when complete
+	tmpPrefix = "."
+)
+
+var (
+	ErrNotFound       = errors.New("snapshot not found")
+	ErrExists         = errors.New("snapshot already exists")
+	ErrInvalidName    = errors.New("invalid snapshot name")
+	validSnapshotName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// Snapshot of a machine, stored in a directory together with the memory, the machine state and the drive copies
+type Snapshot struct {
+	Name    string    `json:"name"`
+	VMID    string    `json:"vmid"`
+	Service string    `json:"service"`
+	IP      string    `json:"ip"`
+	Created time.Time `json:"created"`
+	// Files are the drive copies in the snapshot dir, the first one is the root drive
+	Files []string `json:"files"`
+	// Drives are the drives shared with the host
+	Drives []config.DriveConfig `json:"drives,omitempty"`
+	// Metadata of the machine, set when the machine used MMDS
+	Metadata *config.MetadataConfig `json:"metadata,omitempty"`
+	// Interfaces of the machine with the guest IPs
+	Interfaces []config.InterfaceConfig `json:"interfaces,omitempty"`
+	// Source is the snapshot the machine was started from
+	Source string `json:"source,omitempty"`
+
+	dir string
+}
+
+// RestoreConfig returns the configuration of a machine started from the snapshot
+func (s *Snapshot) RestoreConfig() *config.RestoreConfig {
+	return &config.RestoreConfig{
+		Dir:        s.dir,
+		Files:      append([]string{}, s.Files...),
+		Drives:     append([]config.DriveConfig{}, s.Drives...),
+		IP:         s.IP,
+		Interfaces: append([]config.InterfaceConfig{}, s.Interfaces...),
+		Metadata:   s.Metadata,
+	}
+}
+
+type Store struct {
+	mu  sync.Mutex
+	dir string
+	// creating are the names of the snapshots being taken
+	creating map[string]bool
+}
+
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "creating snapshot dir %s failed", dir)
+	}
+	return &Store{dir: dir, creating: make(map[string]bool)}, nil
+}
+
+// Create stores a new snapshot, take writes the snapshot files into the given dir.
+// The name is reserved while the snapshot is taken, the store is not locked meanwhile.
+func (st *Store) Create(name, vmid, service string, take func(dir string) (config.RestoreConfig, error)) (*Snapshot, error) {
+	if !validSnapshotName.MatchString(name) {
+		return nil, errors.Wrapf(ErrInvalidName, "'%s'", name)
+	}
+	dir := filepath.Join(st.dir, name)
+	if err := st.reserve(name, dir); err != nil {
+		return nil, err
+	}
+	defer st.release(name)
+
+	tmpDir, err := ioutil.TempDir(st.dir, tmpPrefix+name)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating snapshot dir failed")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	restore, err := take(tmpDir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{
+		Name:       name,
+		VMID:       vmid,
+		Service:    service,
+		IP:         restore.IP,
+		Created:    time.Now().UTC(),
+		Files:      restore.Files,
+		Drives:     restore.Drives,
+		Metadata:   restore.Metadata,
+		Interfaces: restore.Interfaces,
+		Source:     restore.Source,
+		dir:        dir,
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding snapshot metadata failed")
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, metadataFile), data, 0600); err != nil {
+		return nil, errors.Wrap(err, "writing snapshot metadata failed")
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return nil, errors.Wrapf(err, "renaming %s failed", tmpDir)
+	}
+	return s, nil
+}
+
+// reserve reserves the name of a new snapshot, it fails when the snapshot exists or is being taken
+func (st *Store) reserve(name, dir string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if _, err := os.Stat(dir); err == nil || st.creating[name] {
+		return errors.Wrapf(ErrExists, "'%s'", name)
+	}
+	st.creating[name] = true
+	return nil
+}
+
+func (st *Store) release(name string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	delete(st.creating, name)
+}
+
+func (st *Store) Get(name string) (*Snapshot, error) {
+	if !validSnapshotName.MatchString(name) {
+		return nil, errors.Wrapf(ErrNotFound, "'%s'", name)
+	}
+	dir := filepath.Join(st.dir, name)
+	data, err := ioutil.ReadFile(filepath.Join(dir, metadataFile))
+	if os.IsNotExist(err) {
+		return nil, errors.Wrapf(ErrNotFound, "'%s'", name)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading snapshot '%s' failed", name)
+	}
+	s := &Snapshot{dir: dir}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrapf(err, "decoding snapshot '%s' failed", name)
+	}
+	if len(s.Files) == 0 {
+		return nil, errors.Errorf("snapshot '%s' has no root drive", name)
+	}
+	return s, nil
+}
+
+func (st *Store) List() ([]Snapshot, error) {
+	files, err := ioutil.ReadDir(st.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading snapshot dir %s failed", st.dir)
+	}
+	result := make([]Snapshot, 0, len(files))
+	for _, file := range files {
+		if !file.IsDir() || strings.HasPrefix(file.Name(), tmpPrefix) {
+			continue
+		}
+		s, err := st.Get(file.Name())
+		if err != nil {
+			continue
+		}
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// Delete removes the snapshot, machines restored from it do not depend on it
+func (st *Store) Delete(name string) error {
+	if _, err := st.Get(name); err != nil {
+		return err
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	dir := filepath.Join(st.dir, name)
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.Wrapf(err, "removing %s failed", dir)
+	}
+	return nil
+}
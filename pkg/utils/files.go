@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+const sparseBlockSize = 64 * 1024
+
+// CloneFile creates dst as a copy of src. A reflink clone sharing the data blocks is used
+// when the filesystem supports it, otherwise the data is copied keeping zero blocks sparse.
+func CloneFile(src, dst string) (reflink bool, err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return false, errors.Wrapf(err, "opening %s failed", src)
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return false, errors.Wrapf(err, "stat %s failed", src)
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return false, errors.Wrapf(err, "creating %s failed", dst)
+	}
+	defer func() {
+		if cerr := out.Close(); cerr != nil && err == nil {
+			err = errors.Wrapf(cerr, "closing %s failed", dst)
+		}
+		if err != nil {
+			_ = os.Remove(dst)
+		}
+	}()
+
+	if unix.IoctlFileClone(int(out.Fd()), int(in.Fd())) == nil {
+		return true, nil
+	}
+	return false, sparseCopy(out, in, info.Size())
+}
+
+func sparseCopy(dst, src *os.File, size int64) error {
+	buf := make([]byte, sparseBlockSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(src, buf)
+		if n > 0 && !isZero(buf[:n]) {
+			if _, werr := dst.WriteAt(buf[:n], offset); werr != nil {
+				return errors.Wrapf(werr, "writing %s failed", dst.Name())
+			}
+		}
+		offset += int64(n)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "reading %s failed", src.Name())
+		}
+	}
+	// extend the file to its full size in case it ends with a hole
+	if err := dst.Truncate(size); err != nil {
+		return errors.Wrapf(err, "truncating %s failed", dst.Name())
+	}
+	return nil
+}
+
+var zeroBlock = make([]byte, sparseBlockSize)
+
+func isZero(b []byte) bool {
+	return bytes.Equal(b, zeroBlock[:len(b)])
+}
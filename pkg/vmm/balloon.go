@@ -0,0 +1,88 @@
+package vmm
+
+import (
+	"time"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNoBalloon   = errors.New("VM has no balloon device")
+	ErrBalloonSize = errors.New("balloon size out of range")
+)
+
+// BalloonStats describes the balloon of a machine, the guest memory statistics are in bytes
+// and are zero when the statistics are disabled
+type BalloonStats struct {
+	TargetMib       int64
+	ActualMib       int64
+	TotalMemory     int64
+	FreeMemory      int64
+	AvailableMemory int64
+	DiskCaches      int64
+	SwapIn          int64
+	SwapOut         int64
+	MajorFaults     int64
+	MinorFaults     int64
+}
+
+func (f *vmm) Balloon() (BalloonStats, error) {
+	if err := f.checkBalloon(); err != nil {
+		return BalloonStats{}, err
+	}
+	if f.vmmConfig.Balloon.StatsInterval < time.Second {
+		balloon, err := f.machine.GetBalloonConfig(f.vmmCtx)
+		if err != nil {
+			return BalloonStats{}, errors.Wrap(err, "getting balloon failed")
+		}
+		return BalloonStats{TargetMib: firecracker.Int64Value(balloon.AmountMib)}, nil
+	}
+	stats, err := f.machine.GetBalloonStats(f.vmmCtx)
+	if err != nil {
+		return BalloonStats{}, errors.Wrap(err, "getting balloon statistics failed")
+	}
+	return BalloonStats{
+		TargetMib:       firecracker.Int64Value(stats.TargetMib),
+		ActualMib:       firecracker.Int64Value(stats.ActualMib),
+		TotalMemory:     stats.TotalMemory,
+		FreeMemory:      stats.FreeMemory,
+		AvailableMemory: stats.AvailableMemory,
+		DiskCaches:      stats.DiskCaches,
+		SwapIn:          stats.SwapIn,
+		SwapOut:         stats.SwapOut,
+		MajorFaults:     stats.MajorFaults,
+		MinorFaults:     stats.MinorFaults,
+	}, nil
+}
+
+// SetBalloon inflates or deflates the balloon to the given size, the guest releases the memory asynchronously
+func (f *vmm) SetBalloon(amountMib int64) error {
+	if err := f.checkBalloon(); err != nil {
+		return err
+	}
+	if amountMib < 0 || amountMib > f.vmmConfig.Machine.MemSizeMib {
+		return errors.Wrapf(ErrBalloonSize, "%d Mib not in [0, %d]", amountMib, f.vmmConfig.Machine.MemSizeMib)
+	}
+	if err := f.machine.UpdateBalloon(f.vmmCtx, amountMib); err != nil {
+		return errors.Wrap(err, "updating balloon failed")
+	}
+	return nil
+}
+
+func (f *vmm) checkBalloon() error {
+	if f.machine == nil {
+		return errors.New("machine is not running")
+	}
+	if !f.vmmConfig.Balloon.Enable {
+		return ErrNoBalloon
+	}
+	return nil
+}
+
+// createBalloonHandler attaches the balloon device before the machine boots,
+// a restored machine has the balloon of the snapshot
+func (f *vmm) createBalloonHandler() firecracker.Handler {
+	b := f.vmmConfig.Balloon
+	return firecracker.NewCreateBalloonHandler(b.AmountMib, b.DeflateOnOOM, int64(b.StatsInterval/time.Second))
+}
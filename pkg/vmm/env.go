@@ -0,0 +1,100 @@
+package vmm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/volume"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfigDriveFile is the read-only drive with the environment of the guest, attached as the last drive.
+	// It is removed with the machine and not copied into the snapshots.
+	ConfigDriveFile = "config.ext4"
+	configDriveID   = "config"
+	configDriveMib  = 4
+)
+
+var ErrEnvOnDrive = errors.New("environment is delivered on the config drive, the VM must be restarted")
+
+// envOnDrive is true when the machine boots with a config drive
+func envOnDrive(c *config.VMMConfig) bool {
+	return c.Env.Delivery == config.EnvDeliveryDrive && len(c.Env.Vars) > 0 && c.Restore == nil
+}
+
+func configDrive(workDir string) models.Drive {
+	return models.Drive{
+		DriveID:      firecracker.String(configDriveID),
+		PathOnHost:   firecracker.String(filepath.Join(workDir, ConfigDriveFile)),
+		IsRootDevice: firecracker.Bool(false),
+		IsReadOnly:   firecracker.Bool(true),
+	}
+}
+
+// createConfigDrive writes the environment as a shell script "env" and as "env.json" into the config drive
+func (f *vmm) createConfigDrive() error {
+	dir, err := ioutil.TempDir(f.workDir, ".config")
+	if err != nil {
+		return errors.Wrap(err, "creating config drive dir failed")
+	}
+	defer os.RemoveAll(dir)
+
+	vars := f.vmmConfig.Env.Vars
+	data, err := json.MarshalIndent(vars, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding environment failed")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "env.json"), data, 0600); err != nil {
+		return errors.Wrap(err, "writing environment failed")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "env"), []byte(shellEnv(vars)), 0600); err != nil {
+		return errors.Wrap(err, "writing environment failed")
+	}
+	if err := volume.CreateDiskFrom(filepath.Join(f.workDir, ConfigDriveFile), configDriveMib, dir); err != nil {
+		return errors.Wrap(err, "config drive creation failed")
+	}
+	return nil
+}
+
+// shellEnv returns the variables as export statements to be sourced by a shell
+func shellEnv(vars map[string]string) string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "export %s='%s'\n", name, strings.ReplaceAll(vars[name], "'", `'\''`))
+	}
+	return b.String()
+}
+
+// SetEnv replaces the environment of the guest in the MMDS
+func (f *vmm) SetEnv(vars map[string]string) error {
+	if f.machine == nil {
+		return errors.New("machine is not running")
+	}
+	if f.vmmConfig.Env.Delivery != config.EnvDeliveryMMDS {
+		return ErrEnvOnDrive
+	}
+	if !allowsMMDS(&f.vmmConfig) {
+		return ErrMMDSDisabled
+	}
+	previous := f.vmmConfig.Env.Vars
+	f.vmmConfig.Env.Vars = vars
+	if err := f.machine.SetMetadata(f.vmmCtx, f.metadata()); err != nil {
+		f.vmmConfig.Env.Vars = previous
+		return errors.Wrap(err, "setting environment failed")
+	}
+	return nil
+}
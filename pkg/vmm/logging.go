@@ -0,0 +1,68 @@
+package vmm
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// firecracker log line, e.g. "2021-03-10T10:12:13.123456789 [anonymous-instance:WARN] message"
+var firecrackerLogLine = regexp.MustCompile(`^\S+ \[[^:\]]*:([A-Za-z]+)[^\]]*\] (.*)$`)
+
+// logWriter forwards the Firecracker log lines received through the log FIFO into the firebox logger
+type logWriter struct {
+	mu     sync.Mutex
+	logger *logrus.Entry
+	buf    []byte
+}
+
+func newLogWriter(logger *logrus.Entry) *logWriter {
+	return &logWriter{logger: logger}
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.log(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *logWriter) log(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	level := logrus.InfoLevel
+	if m := firecrackerLogLine.FindStringSubmatch(line); m != nil {
+		level = toLogrusLevel(m[1])
+		line = m[2]
+	}
+	w.logger.Log(level, line)
+}
+
+func toLogrusLevel(level string) logrus.Level {
+	switch strings.ToLower(level) {
+	case "error":
+		return logrus.ErrorLevel
+	case "warn", "warning":
+		return logrus.WarnLevel
+	case "debug":
+		return logrus.DebugLevel
+	case "trace":
+		return logrus.TraceLevel
+	default:
+		return logrus.InfoLevel
+	}
+}
@@ -0,0 +1,91 @@
+package vmm
+
+import (
+	"context"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/pkg/errors"
+)
+
+const setMetadataHandlerName = "firebox.SetMetadata"
+
+var ErrMMDSDisabled = errors.New("MMDS is not enabled")
+
+// metadata returns the MMDS content, the standard keys are under "firebox" and the caller supplied data under "metadata"
+func (f *vmm) metadata() map[string]interface{} {
+	m := f.vmmConfig.Metadata
+	labels := m.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	standard := map[string]interface{}{
+		"vmid":    f.GetID(),
+		"service": m.Service,
+		"labels":  labels,
+	}
+	if ip := f.GetIP(); ip != nil {
+		standard["ip"] = ip.String()
+	}
+	// the guest configures the interfaces after the first one itself
+	var interfaces []map[string]interface{}
+	for _, iface := range f.interfaces {
+		var addresses []map[string]interface{}
+		for _, a := range iface.Addresses {
+			address := map[string]interface{}{"ip": a.IP.String()}
+			if a.Gateway != nil {
+				address["gateway"] = a.Gateway.String()
+			}
+			addresses = append(addresses, address)
+		}
+		interfaces = append(interfaces, map[string]interface{}{
+			"network":     iface.Network,
+			"ifname":      iface.IfName,
+			"mac":         iface.MacAddress,
+			"ip":          iface.IP.String(),
+			"gateway":     iface.Gateway.String(),
+			"addresses":   addresses,
+			"nameservers": iface.Nameservers,
+		})
+	}
+	if len(interfaces) > 0 {
+		standard["interfaces"] = interfaces
+	}
+	result := map[string]interface{}{
+		"firebox": standard,
+	}
+	if m.Data != nil {
+		result["metadata"] = m.Data
+	}
+	if env := f.vmmConfig.Env; env.Delivery == config.EnvDeliveryMMDS && len(env.Vars) > 0 {
+		result["env"] = env.Vars
+	}
+	return result
+}
+
+// SetMetadata replaces the caller supplied data in the MMDS
+func (f *vmm) SetMetadata(data interface{}) error {
+	if f.machine == nil {
+		return errors.New("machine is not running")
+	}
+	if !allowsMMDS(&f.vmmConfig) {
+		return ErrMMDSDisabled
+	}
+	previous := f.vmmConfig.Metadata.Data
+	f.vmmConfig.Metadata.Data = data
+	if err := f.machine.SetMetadata(f.vmmCtx, f.metadata()); err != nil {
+		f.vmmConfig.Metadata.Data = previous
+		return errors.Wrap(err, "setting metadata failed")
+	}
+	return nil
+}
+
+// setMetadataHandler writes the metadata once the network is set up and the IP is known
+func (f *vmm) setMetadataHandler() firecracker.Handler {
+	return firecracker.Handler{
+		Name: setMetadataHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			return m.SetMetadata(ctx, f.metadata())
+		},
+	}
+}
@@ -0,0 +1,187 @@
+package vmm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/metrics"
+	"github.com/containerd/fifo"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const captureMetricsHandlerName = "firebox.CaptureMetrics"
+
+// Metrics holds the Firecracker metrics of a single machine, grouped like in the Firecracker output,
+// e.g. "vcpu" -> "exit_io_in"
+type Metrics map[string]map[string]int64
+
+// machineMetrics accumulates the Firecracker metrics read from the metrics FIFO.
+// Firecracker emits its counters as deltas since the previous flush, so they are summed up into totals,
+// and its gauges as their current value. The counters are exported by the service of the machine, the gauges
+// by the machine while it runs.
+type machineMetrics struct {
+	mu      sync.Mutex
+	vmid    string
+	service string
+	values  Metrics
+	// consumed is closed when the consumption of the metrics FIFO ended
+	consumed chan struct{}
+}
+
+func newMachineMetrics(vmid, service string) *machineMetrics {
+	return &machineMetrics{
+		vmid:    vmid,
+		service: service,
+		values:  make(Metrics),
+	}
+}
+
+// metricsService returns the service label of the counters of the machine, the services which are not
+// configured share a single label
+func metricsService(c *config.VMMConfig) string {
+	if config.KnownService(c, c.Metadata.Service) {
+		return c.Metadata.Service
+	}
+	return metrics.ServiceUnknown
+}
+
+// isGauge returns true for the store metrics of Firecracker, the latencies and the values which are not counted
+func isGauge(group, name string) bool {
+	switch {
+	case group == "latencies_us":
+		return true
+	case group == "api_server" && strings.HasPrefix(name, "process_startup_time"):
+		return true
+	case group == "seccomp" && name == "num_faults":
+		return true
+	case group == "vmm" && name == "panic_count":
+		return true
+	}
+	// the minimum and maximum of the latency aggregates, e.g. vcpu exit_mmio_read_agg
+	return strings.HasSuffix(name, "_min_us") || strings.HasSuffix(name, "_max_us")
+}
+
+// start consumes the flushed metrics until r is closed
+func (mm *machineMetrics) start(r io.Reader, logger *logrus.Entry) {
+	mm.consumed = make(chan struct{})
+	go func() {
+		defer close(mm.consumed)
+		mm.consume(r, logger)
+	}()
+}
+
+// wait returns once the consumption started by start ended
+func (mm *machineMetrics) wait() {
+	if mm.consumed != nil {
+		<-mm.consumed
+	}
+}
+
+func (mm *machineMetrics) consume(r io.Reader, logger *logrus.Entry) {
+	decoder := json.NewDecoder(r)
+	for {
+		var flush map[string]interface{}
+		if err := decoder.Decode(&flush); err != nil {
+			if err != io.EOF {
+				logger.Debugf("metrics fifo closed: %v", err)
+			}
+			return
+		}
+		mm.add(flush)
+	}
+}
+
+func (mm *machineMetrics) add(flush map[string]interface{}) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	for group, value := range flush {
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			// top level values like utc_timestamp_ms are not metrics
+			continue
+		}
+		if mm.values[group] == nil {
+			mm.values[group] = make(map[string]int64)
+		}
+		flatten("", values, func(name string, v int64) {
+			if isGauge(group, name) {
+				mm.values[group][name] = v
+				metrics.FirecrackerGauges.WithLabelValues(mm.vmid, group, name).Set(float64(v))
+				return
+			}
+			mm.values[group][name] += v
+			metrics.FirecrackerMetrics.WithLabelValues(mm.service, group, name).Add(float64(v))
+		})
+	}
+}
+
+func flatten(prefix string, values map[string]interface{}, fn func(string, int64)) {
+	for name, value := range values {
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+		switch v := value.(type) {
+		case float64:
+			if v >= 0 {
+				fn(name, int64(v))
+			}
+		case map[string]interface{}:
+			flatten(name, v, fn)
+		}
+	}
+}
+
+func (mm *machineMetrics) snapshot() Metrics {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	result := make(Metrics, len(mm.values))
+	for group, values := range mm.values {
+		result[group] = make(map[string]int64, len(values))
+		for name, v := range values {
+			result[group][name] = v
+		}
+	}
+	return result
+}
+
+// reset removes the gauges of the machine from the exported metrics, the counters of the service keep counting
+// the flushes of the machine. The consumption of the metrics FIFO must have ended.
+func (mm *machineMetrics) reset() {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	for group, values := range mm.values {
+		for name := range values {
+			if isGauge(group, name) {
+				metrics.FirecrackerGauges.DeleteLabelValues(mm.vmid, group, name)
+			}
+		}
+	}
+	mm.values = make(Metrics)
+}
+
+// captureMetricsHandler opens the metrics FIFO created by the SDK and consumes the flushed metrics
+func (f *vmm) captureMetricsHandler(logger *logrus.Entry) firecracker.Handler {
+	fifoPath := f.fcConfig.MetricsFifo
+	return firecracker.Handler{
+		Name: captureMetricsHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			pipe, err := fifo.OpenFifo(ctx, fifoPath, syscall.O_RDONLY|syscall.O_NONBLOCK, 0600)
+			if err != nil {
+				return errors.Wrapf(err, "opening metrics fifo %s failed", fifoPath)
+			}
+			f.metricsFifo = pipe
+			f.metrics.start(pipe, logger)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,557 @@
+package vmm
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/network"
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/firecracker-microvm/firecracker-go-sdk/cni/vmconf"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// Interface is a network interface of a machine
+type Interface struct {
+	// Network is the CNI network or the bridge the interface is attached to
+	Network     string
+	HostDevName string
+	MacAddress  string
+	// IfName is the name of the interface in the guest
+	IfName string
+	// IP and Gateway are the primary address of the guest, the first IPv4 address or the first IPv6 address
+	IP      net.IPNet
+	Gateway net.IP
+	// Addresses are all IPv4 and IPv6 addresses of the guest, the primary address first
+	Addresses   []Address
+	Nameservers []string
+	AllowMMDS   bool
+
+	// cni is the runtime config the interface was added to the CNI network with, it is nil on a bridge
+	cni *libcni.RuntimeConf
+	// policyDev is the host device the network policy of the machine is applied to,
+	// the tap device on a bridge and the host side veth on CNI, it is empty without a policy
+	policyDev string
+}
+
+// Address is an IP of the guest with the gateway of its subnet
+type Address struct {
+	IP      net.IPNet
+	Gateway net.IP
+}
+
+// IPs returns all IPs of the guest
+func (i Interface) IPs() []net.IP {
+	var ips []net.IP
+	for _, a := range i.Addresses {
+		ips = append(ips, a.IP.IP)
+	}
+	return ips
+}
+
+// setAddresses sets the addresses of the interface, the first IPv4 address is the primary address
+func (i *Interface) setAddresses(addresses []Address) {
+	primary := 0
+	for j, a := range addresses {
+		if a.IP.IP.To4() != nil {
+			primary = j
+			break
+		}
+	}
+	i.Addresses = append([]Address{addresses[primary]}, addresses[:primary]...)
+	i.Addresses = append(i.Addresses, addresses[primary+1:]...)
+	i.IP, i.Gateway = addresses[primary].IP, addresses[primary].Gateway
+}
+
+// networkCheckTimeout limits the CHECK of the CNI plugins of all interfaces
+const networkCheckTimeout = 30 * time.Second
+
+// ErrRepairUnsupported is returned for the interfaces which can not be attached again while the machine runs
+var ErrRepairUnsupported = errors.New("network repair is not supported")
+
+// Nameserver is the DNS server of the guests, it listens on the gateways of their interfaces
+type Nameserver interface {
+	// Listen serves DNS on the IP to the clients of the subnet, listening on the IP again is a no-op
+	Listen(addr net.IPNet) error
+}
+
+// interfaceConfigs returns the configured interfaces of the machine, the default interface when none is configured
+func interfaceConfigs(c *config.VMMConfig) []config.InterfaceConfig {
+	if len(c.Network.Interfaces) > 0 {
+		return c.Network.Interfaces
+	}
+	return []config.InterfaceConfig{config.DefaultInterface(c)}
+}
+
+// allowsMMDS returns true when MMDS is enabled and served on an interface of the machine
+func allowsMMDS(c *config.VMMConfig) bool {
+	if !c.Network.AllowMMDS {
+		return false
+	}
+	for _, iface := range interfaceConfigs(c) {
+		if iface.AllowMMDS {
+			return true
+		}
+	}
+	return false
+}
+
+// setupNetwork attaches every interface of the machine to its CNI network or bridge.
+// Firebox sets up the interfaces itself since the SDK supports CNI and static IPs for a single interface
+// and a single address only. The guest gets the IPv4 configuration of the first interface by the kernel ip=
+// argument and configures the IPv6 addresses and the other interfaces from the MMDS,
+// a restored guest keeps the configuration of the snapshot.
+func (f *vmm) setupNetwork(ctx context.Context) error {
+	var ifaces firecracker.NetworkInterfaces
+	for i, c := range interfaceConfigs(&f.vmmConfig) {
+		var (
+			iface   *Interface
+			hostDev string
+			err     error
+		)
+		if f.vmmConfig.Network.CNI.Enable {
+			iface, hostDev, err = f.addCNIInterface(ctx, i, c)
+		} else {
+			iface, err = f.addBridgeInterface(c)
+			if iface != nil {
+				hostDev = iface.HostDevName
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "network interface %d on '%s' failed", i, c.Network)
+		}
+		iface.IfName = fmt.Sprintf("eth%d", i)
+		iface.AllowMMDS = f.vmmConfig.Network.AllowMMDS && c.AllowMMDS
+		// the interface is torn down with the machine from now on
+		f.interfaces = append(f.interfaces, *iface)
+		iface = &f.interfaces[len(f.interfaces)-1]
+		if err := f.listenDNS(iface); err != nil {
+			return errors.Wrapf(err, "DNS server of interface %d on '%s' failed", i, c.Network)
+		}
+		if err := f.applyPolicy(iface, hostDev); err != nil {
+			return errors.Wrapf(err, "network policy of interface %d on '%s' failed", i, c.Network)
+		}
+
+		rx, tx := c.NetworkRx, c.NetworkTx
+		if rx == nil {
+			rx = f.vmmConfig.RateLimits.NetworkRx
+		}
+		if tx == nil {
+			tx = f.vmmConfig.RateLimits.NetworkTx
+		}
+		ifaces = append(ifaces, firecracker.NetworkInterface{
+			StaticConfiguration: &firecracker.StaticNetworkConfiguration{
+				MacAddress:  iface.MacAddress,
+				HostDevName: iface.HostDevName,
+			},
+			AllowMMDS:      iface.AllowMMDS,
+			InRateLimiter:  toRateLimiter(rx),
+			OutRateLimiter: toRateLimiter(tx),
+		})
+	}
+	f.fcConfig.NetworkInterfaces = ifaces
+	if f.vmmConfig.Restore == nil && len(f.interfaces) > 0 && f.interfaces[0].IP.IP != nil &&
+		f.interfaces[0].IP.IP.To4() == nil && !allowsMMDS(&f.vmmConfig) {
+		// the kernel ip= argument takes IPv4 only
+		return errors.Wrapf(ErrMMDSDisabled, "the IPv6 only interface %s is configured by the guest from the MMDS", f.interfaces[0].IfName)
+	}
+	if f.vmmConfig.Restore == nil && len(f.interfaces) > 0 && f.interfaces[0].IP.IP.To4() != nil {
+		primary := f.interfaces[0]
+		// the kernel takes the IPv4 nameservers only
+		var nameservers []string
+		for _, ns := range primary.Nameservers {
+			if ip := net.ParseIP(ns); ip != nil && ip.To4() != nil {
+				nameservers = append(nameservers, ns)
+			}
+		}
+		param := vmconf.StaticNetworkConf{
+			VMNameservers: nameservers,
+			VMIPConfig:    &current.IPConfig{Address: primary.IP, Gateway: primary.Gateway},
+			VMIfName:      primary.IfName,
+		}.IPBootParam()
+		f.fcConfig.KernelArgs = f.fcConfig.KernelArgs + " ip=" + param
+	}
+	return nil
+}
+
+// addBridgeInterface connects the machine to a bridge of the built-in network by a tap device named after the guest IP
+func (f *vmm) addBridgeInterface(c config.InterfaceConfig) (*Interface, error) {
+	if f.vmmConfig.NetNS != "" {
+		return nil, errors.New("network namespaces are not supported by the built-in network")
+	}
+	bridge, ok := config.LookupBridge(&f.vmmConfig, c.Network)
+	if !ok {
+		return nil, errors.New("bridge is not configured")
+	}
+	subnet, gateway, err := network.ParseSubnet(bridge.Subnet)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(c.IP).To4()
+	if ip == nil || !subnet.Contains(ip) {
+		return nil, errors.Errorf("guest IP '%s' is not in the bridge subnet '%s'", c.IP, bridge.Subnet)
+	}
+	addresses := []Address{{IP: net.IPNet{IP: ip, Mask: subnet.Mask}, Gateway: gateway}}
+	if bridge.Subnet6 != "" {
+		subnet6, gateway6, err := network.ParseSubnet6(bridge.Subnet6, subnet)
+		if err != nil {
+			return nil, err
+		}
+		ip6 := network.GuestIP6(subnet6, subnet, ip)
+		addresses = append(addresses, Address{IP: net.IPNet{IP: ip6, Mask: subnet6.Mask}, Gateway: gateway6})
+	}
+	tap := network.TapName(ip)
+	if err := network.CreateTap(tap, bridge.Name); err != nil {
+		return nil, err
+	}
+	iface := &Interface{
+		Network:     c.Network,
+		HostDevName: tap,
+		MacAddress:  network.MacAddress(ip),
+		Nameservers: bridge.Nameservers,
+	}
+	iface.setAddresses(addresses)
+	return iface, nil
+}
+
+// addCNIInterface adds the network namespace of the machine to the CNI network,
+// the tap device is created by the tc-redirect-tap plugin. It returns the host device of the interface as well.
+func (f *vmm) addCNIInterface(ctx context.Context, index int, c config.InterfaceConfig) (*Interface, string, error) {
+	ifName := f.vmmConfig.Network.CNI.IfaceName
+	if ifName == "" || index > 0 {
+		ifName = getRandomVethName()
+	}
+	args := [][2]string{{"IgnoreUnknown", "1"}}
+	if index > 0 {
+		// every interface needs its own tap device in the network namespace
+		args = append(args, [2]string{"TC_REDIRECT_TAP_NAME", fmt.Sprintf("tap%d", index)})
+	}
+	if c.IP != "" {
+		// the restored guest keeps the network configuration of the snapshot
+		args = append(args, [2]string{"IP", c.IP})
+	}
+	cniPlugin, networkConfig, err := f.cniNetwork(c.Network)
+	if err != nil {
+		return nil, "", err
+	}
+	rt := &libcni.RuntimeConf{
+		ContainerID:    f.GetID(),
+		NetNS:          f.fcConfig.NetNS,
+		IfName:         ifName,
+		Args:           args,
+		CapabilityArgs: capabilityArgs(c),
+	}
+	result, err := cniPlugin.AddNetworkList(ctx, networkConfig, rt)
+	if err != nil {
+		// the plugins may have left devices or IP allocations behind
+		_ = cniPlugin.DelNetworkList(ctx, networkConfig, rt)
+		return nil, "", errors.Wrap(err, "AddNetworkList failed")
+	}
+	iface := &Interface{Network: c.Network, cni: rt}
+	conf, err := parseCNIResult(result, f.GetID())
+	if err != nil {
+		// the interface is added to be deleted with the machine
+		f.interfaces = append(f.interfaces, *iface)
+		return nil, "", err
+	}
+	iface.HostDevName = conf.tapName
+	iface.MacAddress = conf.mac
+	iface.Nameservers = conf.nameservers
+	iface.setAddresses(conf.addresses)
+	return iface, conf.hostVeth, nil
+}
+
+// cniResult is the configuration of the interface of the guest in the result of the CNI plugins
+type cniResult struct {
+	tapName     string
+	mac         string
+	addresses   []Address
+	nameservers []string
+	// hostVeth is the host side veth, it is empty when the plugins created none
+	hostVeth string
+}
+
+// parseCNIResult finds the tap device and the configuration of the guest in the CNI result. The tc-redirect-tap
+// plugin adds the interface of the guest, named after the tap device with the VMID as sandbox, and moves the IPs of
+// all families to it. The SDK parses the results with a single IP only.
+func parseCNIResult(result types.Result, vmid string) (*cniResult, error) {
+	res, err := current.NewResultFromResult(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing CNI result failed")
+	}
+	guest := -1
+	for i, iface := range res.Interfaces {
+		if iface.Sandbox == vmid {
+			guest = i
+			break
+		}
+	}
+	if guest < 0 {
+		return nil, errors.New("CNI result has no interface of the guest, tc-redirect-tap must be the last plugin")
+	}
+	conf := &cniResult{mac: res.Interfaces[guest].Mac, nameservers: res.DNS.Nameservers}
+	for _, iface := range res.Interfaces {
+		switch {
+		case iface.Sandbox == "":
+			if link, err := netlink.LinkByName(iface.Name); err == nil && link.Type() == "veth" && conf.hostVeth == "" {
+				conf.hostVeth = iface.Name
+			}
+		case iface.Sandbox != vmid && iface.Name == res.Interfaces[guest].Name:
+			conf.tapName = iface.Name
+		}
+	}
+	if conf.tapName == "" {
+		return nil, errors.Errorf("CNI result has no tap device %s", res.Interfaces[guest].Name)
+	}
+	for _, ip := range res.IPs {
+		if ip.Interface != nil && *ip.Interface == guest {
+			conf.addresses = append(conf.addresses, Address{IP: ip.Address, Gateway: ip.Gateway})
+		}
+	}
+	if len(conf.addresses) == 0 {
+		return nil, errors.New("CNI result has no IP of the guest")
+	}
+	return conf, nil
+}
+
+// listenDNS starts the DNS server on the gateways of the interface and makes them the nameservers of the interface,
+// the guest boots with a DNS server answering
+func (f *vmm) listenDNS(iface *Interface) error {
+	if !f.vmmConfig.DNS.Enable {
+		return nil
+	}
+	iface.Nameservers = nil
+	for _, a := range iface.Addresses {
+		if a.Gateway == nil {
+			continue
+		}
+		if err := f.nameserver.Listen(net.IPNet{IP: a.Gateway, Mask: a.IP.Mask}); err != nil {
+			return err
+		}
+		iface.Nameservers = append(iface.Nameservers, a.Gateway.String())
+	}
+	return nil
+}
+
+// applyPolicy applies the network policy of the machine to the host device of the interface
+func (f *vmm) applyPolicy(iface *Interface, hostDev string) error {
+	policy := f.vmmConfig.NetworkPolicy
+	if policy == nil {
+		return nil
+	}
+	if hostDev == "" {
+		return errors.New("the CNI plugins created no host side veth")
+	}
+	iface.policyDev = hostDev
+	return network.ApplyPolicy(hostDev, iface.IPs(), f.interfacePolicy(*iface))
+}
+
+// interfacePolicy returns the network policy of the interface, the guest may query the DNS server on the gateways
+func (f *vmm) interfacePolicy(iface Interface) config.NetworkPolicyConfig {
+	policy := *f.vmmConfig.NetworkPolicy
+	if !f.vmmConfig.DNS.Enable {
+		return policy
+	}
+	var gateways []string
+	for _, a := range iface.Addresses {
+		if a.Gateway != nil {
+			gateways = append(gateways, hostCIDR(a.Gateway))
+		}
+	}
+	if len(gateways) == 0 {
+		return policy
+	}
+	policy.Egress = append(append([]config.NetworkPolicyRule{}, policy.Egress...),
+		config.NetworkPolicyRule{CIDRs: gateways, Ports: []string{"53"}, Protocol: "udp"},
+		config.NetworkPolicyRule{CIDRs: gateways, Ports: []string{"53"}, Protocol: "tcp"},
+	)
+	return policy
+}
+
+func hostCIDR(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+// removePolicy deletes the network policy rules of the interface
+func (f *vmm) removePolicy(iface Interface) {
+	if iface.policyDev == "" {
+		return
+	}
+	if err := network.RemovePolicy(iface.policyDev, iface.IPs()); err != nil {
+		f.logger.Errorf("network policy cleanup failed: %v", err)
+	}
+}
+
+// capabilityArgs returns the CNI runtime config of the interface by capability
+func capabilityArgs(c config.InterfaceConfig) map[string]interface{} {
+	args := make(map[string]interface{})
+	if len(c.PortMappings) > 0 {
+		var mappings []map[string]interface{}
+		for _, pm := range c.PortMappings {
+			protocol := pm.Protocol
+			if protocol == "" {
+				protocol = "tcp"
+			}
+			mapping := map[string]interface{}{
+				"hostPort":      pm.HostPort,
+				"containerPort": pm.GuestPort,
+				"protocol":      protocol,
+			}
+			if pm.HostIP != "" {
+				mapping["hostIP"] = pm.HostIP
+			}
+			mappings = append(mappings, mapping)
+		}
+		args["portMappings"] = mappings
+	}
+	if b := c.Bandwidth; b != nil {
+		args["bandwidth"] = map[string]interface{}{
+			"ingressRate":  b.IngressRate,
+			"ingressBurst": b.IngressBurst,
+			"egressRate":   b.EgressRate,
+			"egressBurst":  b.EgressBurst,
+		}
+	}
+	if len(c.IPs) > 0 {
+		args["ips"] = c.IPs
+	}
+	if c.MAC != "" {
+		args["mac"] = c.MAC
+	}
+	return args
+}
+
+func (f *vmm) cniNetwork(name string) (libcni.CNI, *libcni.NetworkConfigList, error) {
+	cni := &f.vmmConfig.Network.CNI
+	networkConfig, err := libcni.LoadConfList(cni.ConfDir, name)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "LoadConfList failed")
+	}
+	return libcni.NewCNIConfigWithCacheDir([]string{cni.BinDir}, cni.CacheDir, nil), networkConfig, nil
+}
+
+// teardownNetwork deletes the interfaces and the network namespace created for the machine
+func (f *vmm) teardownNetwork() {
+	for _, iface := range f.interfaces {
+		f.removePolicy(iface)
+		if iface.cni != nil {
+			if err := f.cleanupCNINetwork(iface); err != nil {
+				f.logger.Errorf("CNI cleanup failed: %v", err)
+			}
+		} else if err := network.DeleteTap(iface.HostDevName); err != nil {
+			f.logger.Errorf("tap cleanup failed: %v", err)
+		}
+	}
+	if f.vmmConfig.Network.CNI.Enable && len(f.interfaces) > 0 {
+		// clean up the CNI interface directory:
+		ifaceCNIDir := filepath.Join(f.vmmConfig.Network.CNI.CacheDir, f.GetID())
+		f.logger.Infof("cleaning up CNI interface directory '%v'", ifaceCNIDir)
+		if err := os.RemoveAll(ifaceCNIDir); err != nil {
+			f.logger.Errorf("RemoveAll from %s failed: %v", ifaceCNIDir, err)
+		}
+	}
+	f.interfaces = nil
+	if f.ownNetNS {
+		if err := network.DeleteNetNS(f.fcConfig.NetNS); err != nil {
+			f.logger.Errorf("netns cleanup failed: %v", err)
+		}
+	}
+}
+
+func (f *vmm) cleanupCNINetwork(iface Interface) error {
+	f.logger.Infof("cleaning up CNI network '%v' , ifname '%v' and netns '%v'", iface.Network, iface.cni.IfName, f.fcConfig.NetNS)
+
+	cniPlugin, networkConfig, err := f.cniNetwork(iface.Network)
+	if err != nil {
+		return err
+	}
+	// the plugins need the capability args of the add to delete the port mappings and the traffic shaping
+	if err := cniPlugin.DelNetworkList(context.Background(), networkConfig, iface.cni); err != nil {
+		return errors.Wrap(err, "DelNetworkList failed")
+	}
+	return nil
+}
+
+// CheckNetwork checks the attachments of the interfaces: the CNI interfaces by the CHECK of the CNI plugins,
+// the bridge interfaces by the state of the tap device and of the bridge
+func (f *vmm) CheckNetwork() error {
+	ctx, cancel := context.WithTimeout(f.vmmCtx, networkCheckTimeout)
+	defer cancel()
+	for _, iface := range f.interfaces {
+		var err error
+		if iface.cni != nil {
+			err = f.checkCNIInterface(ctx, iface)
+		} else {
+			err = f.checkBridgeInterface(iface)
+		}
+		if err == nil && iface.policyDev != "" {
+			err = network.CheckPolicy(iface.policyDev, iface.IPs())
+		}
+		if err != nil {
+			return errors.Wrapf(err, "network interface %s on '%s'", iface.IfName, iface.Network)
+		}
+	}
+	return nil
+}
+
+func (f *vmm) checkCNIInterface(ctx context.Context, iface Interface) error {
+	cniPlugin, networkConfig, err := f.cniNetwork(iface.Network)
+	if err != nil {
+		return err
+	}
+	if err := cniPlugin.CheckNetworkList(ctx, networkConfig, iface.cni); err != nil {
+		return errors.Wrap(err, "CheckNetworkList failed")
+	}
+	return nil
+}
+
+func (f *vmm) checkBridgeInterface(iface Interface) error {
+	bridge, ok := config.LookupBridge(&f.vmmConfig, iface.Network)
+	if !ok {
+		return errors.New("bridge is not configured")
+	}
+	return network.CheckTap(iface.HostDevName, bridge)
+}
+
+// RepairNetwork sets up the bridges of the built-in network again and attaches the tap devices of the machine,
+// the tap devices are persistent and survive the deletion of a bridge. The CNI interfaces and the deleted tap devices
+// can not be repaired since Firecracker keeps the device it has opened, a new device would not reach the guest.
+func (f *vmm) RepairNetwork() error {
+	for _, iface := range f.interfaces {
+		if iface.cni != nil {
+			return errors.Wrapf(ErrRepairUnsupported, "network interface %s on CNI network '%s'", iface.IfName, iface.Network)
+		}
+	}
+	for _, iface := range f.interfaces {
+		bridge, ok := config.LookupBridge(&f.vmmConfig, iface.Network)
+		if !ok {
+			return errors.Errorf("bridge '%s' is not configured", iface.Network)
+		}
+		if err := network.EnsureBridge(bridge); err != nil {
+			return err
+		}
+		if err := network.AttachTap(iface.HostDevName, bridge.Name); err != nil {
+			if errors.Is(err, network.ErrTapNotFound) {
+				return errors.Wrapf(ErrRepairUnsupported, "network interface %s: %v", iface.IfName, err)
+			}
+			return err
+		}
+		if iface.policyDev != "" {
+			if err := network.ApplyPolicy(iface.policyDev, iface.IPs(), f.interfacePolicy(iface)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
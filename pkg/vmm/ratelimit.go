@@ -0,0 +1,41 @@
+package vmm
+
+import (
+	"time"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+)
+
+// toRateLimiter returns nil when there is nothing to limit
+func toRateLimiter(c *config.RateLimiterConfig) *models.RateLimiter {
+	if c == nil || (c.Bandwidth == nil && c.Ops == nil) {
+		return nil
+	}
+	return &models.RateLimiter{
+		Bandwidth: toTokenBucket(c.Bandwidth),
+		Ops:       toTokenBucket(c.Ops),
+	}
+}
+
+func toTokenBucket(c *config.TokenBucketConfig) *models.TokenBucket {
+	if c == nil {
+		return nil
+	}
+	bucket := &models.TokenBucket{
+		Size:       firecracker.Int64(c.Size),
+		RefillTime: firecracker.Int64(int64(c.RefillTime / time.Millisecond)),
+	}
+	if c.OneTimeBurst > 0 {
+		bucket.OneTimeBurst = firecracker.Int64(c.OneTimeBurst)
+	}
+	return bucket
+}
+
+func driveOpts(c *config.RateLimiterConfig) []firecracker.DriveOpt {
+	if limiter := toRateLimiter(c); limiter != nil {
+		return []firecracker.DriveOpt{firecracker.WithRateLimiter(*limiter)}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package vmm
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/utils"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	SnapshotMemFile   = "memory"
+	SnapshotStateFile = "vmstate"
+
+	relativeDrivePathsHandlerName = "firebox.RelativeDrivePaths"
+)
+
+var errJailerSnapshot = errors.New("snapshots are not supported with the jailer")
+
+// Snapshot pauses the machine, writes its memory, its state and copies of its drives into dir and resumes it.
+// A paused machine stays paused.
+func (f *vmm) Snapshot(dir string) (restore config.RestoreConfig, err error) {
+	if f.machine == nil {
+		return restore, errors.New("machine is not running")
+	}
+	if f.fcConfig.JailerCfg != nil {
+		return restore, errJailerSnapshot
+	}
+	var shared []config.DriveConfig
+	for _, drive := range f.vmmConfig.Drives {
+		if drive.Volume != "" {
+			return restore, errors.Errorf("machine with the persistent volume '%s' can not be snapshotted", drive.Volume)
+		}
+		if drive.Path != "" {
+			shared = append(shared, drive)
+		}
+	}
+
+	if !f.paused {
+		if err := f.Pause(); err != nil {
+			return restore, err
+		}
+		defer func() {
+			if rerr := f.Resume(); rerr != nil && err == nil {
+				err = rerr
+			}
+		}()
+	}
+	if err := f.machine.CreateSnapshot(f.vmmCtx, filepath.Join(dir, SnapshotMemFile), filepath.Join(dir, SnapshotStateFile)); err != nil {
+		return restore, errors.Wrap(err, "creating snapshot failed")
+	}
+	// the drives are copied while the machine is paused to match the memory,
+	// the config drive with the environment in plaintext is created again on restore
+	for _, file := range f.files {
+		if file == ConfigDriveFile {
+			continue
+		}
+		if _, err := utils.CloneFile(filepath.Join(f.workDir, file), filepath.Join(dir, file)); err != nil {
+			return restore, errors.Wrapf(err, "copying drive %s failed", file)
+		}
+	}
+	restore = config.RestoreConfig{
+		Dir:    dir,
+		Files:  f.files,
+		Drives: shared,
+	}
+	if ip := f.GetIP(); ip != nil {
+		restore.IP = ip.String()
+	}
+	// the restored machine needs the same interfaces and guest IPs
+	for i, c := range interfaceConfigs(&f.vmmConfig) {
+		if i < len(f.interfaces) && f.interfaces[i].IP.IP != nil {
+			c.IP = f.interfaces[i].IP.IP.String()
+			if f.interfaces[i].cni != nil {
+				// the IPAM plugin assigns all addresses of a dual-stack guest again
+				var ips []string
+				for _, ip := range f.interfaces[i].IPs() {
+					ips = append(ips, ip.String())
+				}
+				c.IP = strings.Join(ips, ",")
+			}
+		}
+		restore.Interfaces = append(restore.Interfaces, c)
+	}
+	if allowsMMDS(&f.vmmConfig) {
+		metadata := f.vmmConfig.Metadata
+		restore.Metadata = &metadata
+	}
+	return restore, nil
+}
+
+// relativeDrivePathsHandler attaches the drives in the work dir by paths relative to it.
+// Firecracker keeps the drive paths in the snapshot, the relative paths let a snapshot be restored
+// in the work dir of another machine since Firecracker runs in the work dir.
+func (f *vmm) relativeDrivePathsHandler() firecracker.Handler {
+	return firecracker.Handler{
+		Name: relativeDrivePathsHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			drives := make([]models.Drive, len(m.Cfg.Drives))
+			for i, drive := range m.Cfg.Drives {
+				rel, err := filepath.Rel(f.workDir, firecracker.StringValue(drive.PathOnHost))
+				if err == nil && !strings.HasPrefix(rel, "..") {
+					drive.PathOnHost = firecracker.String(rel)
+				}
+				drives[i] = drive
+			}
+			m.Cfg.Drives = drives
+			return nil
+		},
+	}
+}
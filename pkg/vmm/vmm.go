@@ -14,8 +14,11 @@ import (
 	"time"
 
 	"github.com/combust-labs/firebox/config"
+	"github.com/combust-labs/firebox/pkg/console"
 	"github.com/combust-labs/firebox/pkg/log"
-	"github.com/containernetworking/cni/libcni"
+	"github.com/combust-labs/firebox/pkg/network"
+	"github.com/combust-labs/firebox/pkg/utils"
+	"github.com/combust-labs/firebox/pkg/volume"
 	"github.com/firecracker-microvm/firecracker-go-sdk"
 	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
 	"github.com/gofrs/uuid"
@@ -35,6 +38,18 @@ type VMM interface {
 	Stop() error
 	GetIP() net.IP
 	GetID() string
+	Metrics() Metrics
+	Console() *console.Console
+	Snapshot(dir string) (config.RestoreConfig, error)
+	Pause() error
+	Resume() error
+	Balloon() (BalloonStats, error)
+	SetBalloon(amountMib int64) error
+	SetMetadata(data interface{}) error
+	SetEnv(vars map[string]string) error
+	Interfaces() []Interface
+	CheckNetwork() error
+	RepairNetwork() error
 }
 
 type vmm struct {
@@ -44,39 +59,73 @@ type vmm struct {
 	vmmCtx          context.Context
 	shutdownTimeout time.Duration
 	vmmConfig       config.VMMConfig
-
-	machine *firecracker.Machine
+	workDir         string
+	rootfs          string
+	// files are the names of the drives in the work dir, the first one is the root drive
+	files        []string
+	scratchDisks []config.DriveConfig
+
+	// interfaces are the network interfaces set up for the machine, the first one is the primary interface
+	interfaces []Interface
+	// ownNetNS is set when the network namespace is created for the machine and deleted with it
+	ownNetNS bool
+
+	// nameserver is the DNS server of the guest when DNS is enabled
+	nameserver Nameserver
+
+	machine     *firecracker.Machine
+	paused      bool
+	metrics     *machineMetrics
+	metricsFifo io.Closer
+	console     *console.Console
 }
 
-func NewVMM(logger *log.Logger, vmmConfig config.VMMConfig) VMM {
-	var fifo io.WriteCloser
+// NewVMM returns the machine of the config, without a nameserver the guest keeps the configured nameservers
+func NewVMM(logger *log.Logger, vmmConfig config.VMMConfig, nameserver Nameserver) VMM {
 	vmmID := uuid.Must(uuid.NewV4()).String()
 	logger.Infof("Starting VMM ID %s", vmmID)
 
+	if nameserver == nil {
+		vmmConfig.DNS.Enable = false
+	}
+	workDir := filepath.Join(vmmConfig.WorkDir, "vms", vmmID)
+	// every machine gets its own writable copy of the root disk image
+	files := []string{"rootfs" + filepath.Ext(vmmConfig.RootFS)}
+	if vmmConfig.Restore != nil {
+		files = vmmConfig.Restore.Files
+	}
+	netNS := vmmConfig.NetNS
+	if netNS == "" && vmmConfig.Network.CNI.Enable {
+		netNS = filepath.Join(vmmConfig.NetNSDir, vmmID)
+	}
+	drives, files, scratchDisks := getDrives(workDir, files, vmmConfig.Drives, vmmConfig.RateLimits.Drive)
+	if envOnDrive(&vmmConfig) {
+		drives = append(drives, configDrive(workDir))
+		files = append(files, ConfigDriveFile)
+	}
 	fcConfig := &firecracker.Config{
-		SocketPath:        getSocketPath(&vmmConfig),
-		LogFifo:           "",
-		LogLevel:          vmmConfig.LogLevel,
-		MetricsFifo:       "",
-		KernelImagePath:   vmmConfig.KernelImage,
-		InitrdPath:        "",
-		KernelArgs:        vmmConfig.KernelArgs,
-		Drives:            firecracker.NewDrivesBuilder(vmmConfig.RootFS).Build(),
-		NetworkInterfaces: getNetworkInterfaces(&vmmConfig),
-		FifoLogWriter:     fifo,
-		VsockDevices:      []firecracker.VsockDevice{},
+		SocketPath:      getSocketPath(&vmmConfig),
+		LogFifo:         filepath.Join(workDir, "firecracker.log"),
+		LogLevel:        vmmConfig.LogLevel,
+		MetricsFifo:     filepath.Join(workDir, "firecracker.metrics"),
+		KernelImagePath: vmmConfig.KernelImage,
+		InitrdPath:      vmmConfig.Initrd,
+		KernelArgs:      vmmConfig.KernelArgs,
+		Drives:          drives,
+		FifoLogWriter:   newLogWriter(logger.RawLogger().WithField("vmid", vmmID).WithField("subsystem", "firecracker")),
+		VsockDevices:    []firecracker.VsockDevice{},
 		MachineCfg: models.MachineConfiguration{
 			CPUTemplate: models.CPUTemplate(vmmConfig.Machine.CPUTemplate),
-			HtEnabled:   firecracker.Bool(vmmConfig.Machine.HtEnabled),
+			Smt:         firecracker.Bool(vmmConfig.Machine.HtEnabled),
 			MemSizeMib:  firecracker.Int64(vmmConfig.Machine.MemSizeMib),
 			VcpuCount:   firecracker.Int64(vmmConfig.Machine.VcpuCount),
 		},
 		DisableValidation: false,
 		JailerCfg:         getJailerConfig(&vmmConfig),
 		VMID:              vmmID,
-		NetNS:             vmmConfig.NetNS,
+		NetNS:             netNS,
 		ForwardSignals:    nil,
-		SeccompLevel:      firecracker.SeccompLevelDisable,
+		Seccomp:           firecracker.SeccompConfig{Enabled: false},
 	}
 	return &vmm{
 		logger:          logger,
@@ -84,6 +133,13 @@ func NewVMM(logger *log.Logger, vmmConfig config.VMMConfig) VMM {
 		vmmCtx:          context.Background(),
 		shutdownTimeout: vmmConfig.VMM.ShutdownTimeout,
 		vmmConfig:       vmmConfig,
+		workDir:         workDir,
+		rootfs:          filepath.Join(workDir, files[0]),
+		files:           files,
+		scratchDisks:    scratchDisks,
+		ownNetNS:        netNS != vmmConfig.NetNS,
+		nameserver:      nameserver,
+		metrics:         newMachineMetrics(vmmID, metricsService(&vmmConfig)),
 	}
 }
 
@@ -91,7 +147,36 @@ func (f *vmm) GetID() string {
 	return f.fcConfig.VMID
 }
 
-func (f *vmm) Start() error {
+func (f *vmm) Start() (err error) {
+	if err := os.MkdirAll(f.workDir, 0700); err != nil {
+		return errors.Wrapf(err, "creating work dir %s failed", f.workDir)
+	}
+	defer func() {
+		if err != nil {
+			f.teardownNetwork()
+			f.cleanup()
+		}
+	}()
+	if err := f.createDrives(); err != nil {
+		return err
+	}
+	if f.ownNetNS {
+		if err := network.CreateNetNS(f.fcConfig.NetNS); err != nil {
+			return err
+		}
+	}
+	if err := f.setupNetwork(f.vmmCtx); err != nil {
+		return err
+	}
+	var writers []io.Writer
+	if f.vmmConfig.Console.Attach {
+		writers = append(writers, os.Stdout)
+	}
+	c, err := console.New(f.workDir, f.vmmConfig.Console, writers...)
+	if err != nil {
+		return errors.Wrap(err, "console creation failed")
+	}
+	f.console = c
 	machine, err := f.runVMM(f.vmmCtx)
 	if err != nil {
 		return errors.Wrap(err, "runVMM failed")
@@ -100,6 +185,39 @@ func (f *vmm) Start() error {
 	return nil
 }
 
+// createDrives creates the drives of the machine in the work dir
+func (f *vmm) createDrives() error {
+	if r := f.vmmConfig.Restore; r != nil {
+		for _, file := range r.Files {
+			if file == ConfigDriveFile {
+				// the config drive is not part of the snapshot, it is created with the current environment
+				if err := f.createConfigDrive(); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := utils.CloneFile(filepath.Join(r.Dir, file), filepath.Join(f.workDir, file)); err != nil {
+				return errors.Wrapf(err, "restoring drive %s failed", file)
+			}
+		}
+		return nil
+	}
+	reflink, err := utils.CloneFile(f.vmmConfig.RootFS, f.rootfs)
+	if err != nil {
+		return errors.Wrap(err, "rootfs copy failed")
+	}
+	f.logger.Infof("Copied rootfs %s to %s (reflink: %v)", f.vmmConfig.RootFS, f.rootfs, reflink)
+	for _, disk := range f.scratchDisks {
+		if err := volume.CreateDisk(disk.Path, disk.SizeMib); err != nil {
+			return errors.Wrap(err, "scratch disk creation failed")
+		}
+	}
+	if envOnDrive(&f.vmmConfig) {
+		return f.createConfigDrive()
+	}
+	return nil
+}
+
 func (f *vmm) WaitFinished() error {
 	if err := f.machine.Wait(f.vmmCtx); err != nil {
 		return errors.Wrap(err, "machine.Wait failed")
@@ -111,25 +229,109 @@ func (f *vmm) Stop() error {
 	if f.machine != nil {
 		f.stopVMM(f.vmmCtx, f.machine)
 	}
+	f.cleanup()
 	return nil
 }
 
-func (f *vmm) GetIP() net.IP {
-	if len(f.fcConfig.NetworkInterfaces) > 0 {
-		ni0 := &f.fcConfig.NetworkInterfaces[0]
-		if ni0.StaticConfiguration != nil && ni0.StaticConfiguration.IPConfiguration != nil {
-			return ni0.StaticConfiguration.IPConfiguration.IPAddr.IP
+// cleanup closes the metrics FIFO and the console and removes the work dir of the stopped or failed machine
+func (f *vmm) cleanup() {
+	if f.metricsFifo != nil {
+		_ = f.metricsFifo.Close()
+		// the flushes read before the close must not add the machine to the metrics again
+		f.metrics.wait()
+	}
+	f.metrics.reset()
+	if f.console != nil {
+		_ = f.console.Close()
+	}
+	if f.vmmConfig.KeepRootFS {
+		// the config drive holds the environment in plaintext
+		if err := os.Remove(filepath.Join(f.workDir, ConfigDriveFile)); err != nil && !os.IsNotExist(err) {
+			f.logger.Errorf("config drive cleanup failed: %v", err)
 		}
+		f.logger.Infof("Keeping work dir %s with rootfs copy %s", f.workDir, f.rootfs)
+	} else if err := os.RemoveAll(f.workDir); err != nil {
+		f.logger.Errorf("work dir cleanup failed: %v", err)
+	}
+}
+
+func (f *vmm) Pause() error {
+	if f.machine == nil {
+		return errors.New("machine is not running")
+	}
+	if err := f.machine.PauseVM(f.vmmCtx); err != nil {
+		return errors.Wrap(err, "pausing machine failed")
+	}
+	f.paused = true
+	return nil
+}
+
+func (f *vmm) Resume() error {
+	if f.machine == nil {
+		return errors.New("machine is not running")
+	}
+	if err := f.machine.ResumeVM(f.vmmCtx); err != nil {
+		return errors.Wrap(err, "resuming machine failed")
 	}
+	f.paused = false
 	return nil
 }
 
+func (f *vmm) Metrics() Metrics {
+	return f.metrics.snapshot()
+}
+
+func (f *vmm) Console() *console.Console {
+	return f.console
+}
+
+// GetIP returns the guest IP of the primary interface
+func (f *vmm) GetIP() net.IP {
+	if len(f.interfaces) > 0 {
+		return f.interfaces[0].IP.IP
+	}
+	return nil
+}
+
+func (f *vmm) Interfaces() []Interface {
+	return append([]Interface{}, f.interfaces...)
+}
+
 func (f *vmm) runVMM(ctx context.Context) (*firecracker.Machine, error) {
 	logger := f.logger.RawLogger().WithField("vmid", f.fcConfig.VMID).WithField("subsystem", "firecracker-sdk")
 
 	opts := []firecracker.Opt{
 		firecracker.WithLogger(logger),
 	}
+	// the serial console of the guest is the stdin and stdout of the firecracker process
+	var stdin io.Reader = f.console.Stdin()
+	if f.vmmConfig.Console.Attach {
+		stdin = os.Stdin
+	}
+	if f.fcConfig.JailerCfg != nil {
+		if f.vmmConfig.Restore != nil {
+			return nil, errJailerSnapshot
+		}
+		f.fcConfig.JailerCfg.Stdin = stdin
+		f.fcConfig.JailerCfg.Stdout = f.console
+	} else {
+		cmd := firecracker.VMCommandBuilder{}.
+			WithBin("firecracker").
+			WithSocketPath(f.fcConfig.SocketPath).
+			AddArgs("--id", f.fcConfig.VMID, "--no-seccomp").
+			WithStdin(stdin).
+			WithStdout(f.console).
+			WithStderr(os.Stderr).
+			Build(ctx)
+		// the drives in the work dir are attached relative to it, see relativeDrivePathsHandler
+		cmd.Dir = f.workDir
+		opts = append(opts, firecracker.WithProcessRunner(cmd))
+	}
+	if r := f.vmmConfig.Restore; r != nil {
+		opts = append(opts, firecracker.WithSnapshot(filepath.Join(r.Dir, SnapshotMemFile), filepath.Join(r.Dir, SnapshotStateFile), func(c *firecracker.SnapshotConfig) {
+			c.ResumeVM = true
+		}))
+	}
 	m, err := firecracker.NewMachine(ctx, *f.fcConfig, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "Machine creation failed")
@@ -144,6 +346,18 @@ func (f *vmm) runVMM(ctx context.Context) (*firecracker.Machine, error) {
 	if len(m.Cfg.SocketPath) > MaxSocketPathLength {
 		return nil, errors.Errorf("Socket path too long %d, this will generate 'path must be shorter than SUN_LEN'", len(m.Cfg.SocketPath))
 	}
+	m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.CreateLogFilesHandlerName, f.captureMetricsHandler(logger))
+	if f.fcConfig.JailerCfg == nil {
+		m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.CreateMachineHandlerName, f.relativeDrivePathsHandler())
+	}
+	if f.vmmConfig.Balloon.Enable && f.vmmConfig.Restore == nil {
+		m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.CreateMachineHandlerName, f.createBalloonHandler())
+	}
+	if allowsMMDS(&f.vmmConfig) {
+		// the MMDS content is not part of a snapshot
+		m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.ConfigMmdsHandlerName, f.setMetadataHandler())
+		m.Handlers.FcInit = m.Handlers.FcInit.AppendAfter(firecracker.LoadSnapshotHandlerName, f.setMetadataHandler())
+	}
 
 	if err := m.Start(ctx); err != nil {
 		return nil, errors.Wrap(err, "Machine start failed")
@@ -175,14 +389,7 @@ func (f *vmm) stopVMM(ctx context.Context, machine *firecracker.Machine) {
 		f.logger.Warnf("VMM stopped forcefully: %v ", machine.StopVMM()) // force stop
 	}
 
-	for _, iface := range f.fcConfig.NetworkInterfaces {
-		cni := iface.CNIConfiguration
-		if cni != nil {
-			if err := f.cleanupCNINetwork(machine.Cfg.VMID, f.fcConfig.NetNS, cni.NetworkName, cni.IfName, &f.vmmConfig.Network.CNI); err != nil {
-				f.logger.Errorf("CNI cleanup failed: %v", err)
-			}
-		}
-	}
+	f.teardownNetwork()
 	if f.fcConfig.JailerCfg != nil {
 		if err := f.cleanupJailerChrootBaseDir(machine.Cfg.JailerCfg.ID, &f.vmmConfig.Jailer); err != nil {
 			f.logger.Errorf("chroot dir cleanup failed: %v", err)
@@ -190,40 +397,6 @@ func (f *vmm) stopVMM(ctx context.Context, machine *firecracker.Machine) {
 	}
 }
 
-func (f *vmm) cleanupCNINetwork(vmmID, netNS, networkName, ifname string, cni *config.CNIConfig) error {
-	f.logger.Infof("cleaning up CNI network '%v' , ifname '%v' and netns '%v'", networkName, ifname, netNS)
-
-	cniPlugin := libcni.NewCNIConfigWithCacheDir([]string{cni.BinDir}, cni.CacheDir, nil)
-	networkConfig, err := libcni.LoadConfList(cni.ConfDir, networkName)
-	if err != nil {
-		return errors.Wrap(err, "LoadConfList failed")
-	}
-	if err := cniPlugin.DelNetworkList(context.Background(), networkConfig, &libcni.RuntimeConf{
-		ContainerID: vmmID, // golang firecracker SDK uses the VMID, if VMID is set
-		NetNS:       netNS,
-		IfName:      ifname,
-	}); err != nil {
-		return errors.Wrap(err, "DelNetworkList failed")
-	}
-	// clean up the CNI interface directory:
-	ifaceCNIDir := filepath.Join(cni.CacheDir, vmmID)
-
-	f.logger.Infof("cleaning up CNI interface directory '%v'", ifaceCNIDir)
-
-	ifaceCNIDirStat, err := os.Stat(ifaceCNIDir)
-	if err != nil {
-		return errors.Wrapf(err, "os.Stat(%s) failed", ifaceCNIDir)
-	}
-	if !ifaceCNIDirStat.IsDir() {
-		return errors.Wrapf(err, "%s is not a dir", ifaceCNIDir)
-	}
-	err = os.RemoveAll(ifaceCNIDir)
-	if err != nil {
-		return errors.Wrapf(err, "RemoveAll from %s failed", ifaceCNIDir)
-	}
-	return nil
-}
-
 func (f *vmm) cleanupJailerChrootBaseDir(jailerVMId string, jailer *config.JailerConfig) error {
 	// delete only from /srv subdir - prevent OS deletion  ;-)
 	if !strings.HasPrefix(jailer.ChrootBaseDir, "/srv/") {
@@ -257,28 +430,30 @@ func getJailerConfig(c *config.VMMConfig) *firecracker.JailerConfig {
 		ChrootBaseDir:  c.Jailer.ChrootBaseDir,
 		Daemonize:      c.Jailer.Daemonize,
 		ChrootStrategy: firecracker.NewNaiveChrootStrategy(c.KernelImage),
-		Stdout:         os.Stdout,
 		Stderr:         os.Stderr,
-		Stdin:          nil,
 	}
 }
 
-func getNetworkInterfaces(c *config.VMMConfig) firecracker.NetworkInterfaces {
-	vethIfaceName := c.Network.CNI.IfaceName
-	if vethIfaceName == "" {
-		vethIfaceName = getRandomVethName()
-	}
-	return firecracker.NetworkInterfaces{
-		firecracker.NetworkInterface{
-			// TODO: check if cni is enabled
-			CNIConfiguration: &firecracker.CNIConfiguration{
-				// NetworkConfig: TODO: provide fcConfig
-				NetworkName: c.Network.CNI.NetworkName,
-				IfName:      vethIfaceName,
-			},
-			AllowMMDS: c.Network.AllowMMDS,
-		},
+// getDrives returns the drives in the work dir followed by the additional drives in their order.
+// The scratch disks get a file in the work dir and are returned to be created on start.
+func getDrives(workDir string, files []string, drives []config.DriveConfig, limiter *config.RateLimiterConfig) ([]models.Drive, []string, []config.DriveConfig) {
+	opts := driveOpts(limiter)
+	builder := firecracker.DrivesBuilder{}.WithRootDrive(filepath.Join(workDir, files[0]), opts...)
+	for _, file := range files[1:] {
+		builder = builder.AddDrive(filepath.Join(workDir, file), false, opts...)
+	}
+	files = append([]string{}, files...)
+	var scratchDisks []config.DriveConfig
+	for i, drive := range drives {
+		if drive.Path == "" {
+			file := fmt.Sprintf("scratch-%d.ext4", i)
+			files = append(files, file)
+			drive.Path = filepath.Join(workDir, file)
+			scratchDisks = append(scratchDisks, drive)
+		}
+		builder = builder.AddDrive(drive.Path, drive.ReadOnly, opts...)
 	}
+	return builder.Build(), files, scratchDisks
 }
 
 func getSocketPath(c *config.VMMConfig) string {
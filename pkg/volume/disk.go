@@ -0,0 +1,54 @@
+package volume
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+const mib = 1024 * 1024
+
+// ValidateSize checks the size of a disk requested for a machine is positive and at most maxMib
+func ValidateSize(sizeMib, maxMib int64) error {
+	if sizeMib <= 0 || sizeMib > maxMib {
+		return errors.Wrapf(ErrInvalidSize, "%d MiB not in [1, %d]", sizeMib, maxMib)
+	}
+	return nil
+}
+
+// CreateDisk creates a sparse disk image of the given size formatted with ext4
+func CreateDisk(path string, sizeMib int64) error {
+	return CreateDiskFrom(path, sizeMib, "")
+}
+
+// CreateDiskFrom creates a disk image like CreateDisk populated with the content of dir
+func CreateDiskFrom(path string, sizeMib int64, dir string) (err error) {
+	if sizeMib <= 0 {
+		return errors.Wrapf(ErrInvalidSize, "%d MiB", sizeMib)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "creating %s failed", path)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+	err = f.Truncate(sizeMib * mib)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "allocating %s failed", path)
+	}
+	args := []string{"-q", "-F"}
+	if dir != "" {
+		args = append(args, "-d", dir)
+	}
+	if out, err := exec.Command("mkfs.ext4", append(args, path)...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "mkfs.ext4 %s failed: %s", path, out)
+	}
+	return nil
+}
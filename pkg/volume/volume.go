@@ -0,0 +1,183 @@
+package volume
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const fileExt = ".ext4"
+
+var (
+	ErrNotFound     = errors.New("volume not found")
+	ErrExists       = errors.New("volume already exists")
+	ErrAttached     = errors.New("volume is attached to a VM")
+	ErrInvalidName  = errors.New("invalid volume name")
+	ErrInvalidSize  = errors.New("invalid disk size")
+	validVolumeName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+type Volume struct {
+	Name    string
+	SizeMib int64
+	Path    string
+	// AttachedTo is the ID of the VM using the volume, empty when the volume is free
+	AttachedTo string
+	attached   bool
+}
+
+// Store keeps the persistent volumes as disk images in a directory.
+// A volume can be attached to a single VM at a time, the attachments are not persisted
+// since the VMs do not survive a restart of firebox.
+type Store struct {
+	mu       sync.Mutex
+	dir      string
+	attached map[string]string
+	// maxSizeMib limits the size of the created volumes
+	maxSizeMib int64
+}
+
+func NewStore(dir string, maxSizeMib int64) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "creating volume dir %s failed", dir)
+	}
+	return &Store{
+		dir:        dir,
+		attached:   make(map[string]string),
+		maxSizeMib: maxSizeMib,
+	}, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+fileExt)
+}
+
+func (s *Store) Create(name string, sizeMib int64) (*Volume, error) {
+	if !validVolumeName.MatchString(name) {
+		return nil, errors.Wrapf(ErrInvalidName, "'%s'", name)
+	}
+	if err := ValidateSize(sizeMib, s.maxSizeMib); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Wrapf(ErrExists, "'%s'", name)
+	}
+	if err := CreateDisk(path, sizeMib); err != nil {
+		return nil, err
+	}
+	return s.get(name)
+}
+
+func (s *Store) Get(name string) (*Volume, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(name)
+}
+
+func (s *Store) get(name string) (*Volume, error) {
+	if !validVolumeName.MatchString(name) {
+		return nil, errors.Wrapf(ErrNotFound, "'%s'", name)
+	}
+	path := s.path(name)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, errors.Wrapf(ErrNotFound, "'%s'", name)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "stat %s failed", path)
+	}
+	vmid, attached := s.attached[name]
+	return &Volume{
+		Name:       name,
+		SizeMib:    info.Size() / mib,
+		Path:       path,
+		AttachedTo: vmid,
+		attached:   attached,
+	}, nil
+}
+
+func (s *Store) List() ([]Volume, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading volume dir %s failed", s.dir)
+	}
+	result := make([]Volume, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), fileExt) {
+			continue
+		}
+		v, err := s.get(strings.TrimSuffix(file.Name(), fileExt))
+		if err != nil {
+			continue
+		}
+		result = append(result, *v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, err := s.get(name)
+	if err != nil {
+		return err
+	}
+	if v.attached {
+		return errors.Wrapf(ErrAttached, "'%s'", name)
+	}
+	if err := os.Remove(v.Path); err != nil {
+		return errors.Wrapf(err, "removing %s failed", v.Path)
+	}
+	return nil
+}
+
+// Reserve marks the volume as attached before the VM using it is started, see Bind and Release
+func (s *Store) Reserve(name string) (*Volume, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, err := s.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if v.attached {
+		return nil, errors.Wrapf(ErrAttached, "'%s'", name)
+	}
+	s.attached[name] = ""
+	return v, nil
+}
+
+// Bind records the VM a reserved volume is attached to
+func (s *Store) Bind(name, vmid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.attached[name]; ok {
+		s.attached[name] = vmid
+	}
+}
+
+// Release detaches the volume
+func (s *Store) Release(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attached, name)
+}